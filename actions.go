@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var actionsUsage = `Specify an actions command to execute:
+  - runs: List the workflow runs of a repository
+  - logs: Print the logs of a workflow run`
+
+func executeActions(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(actionsUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("actions", "command", command)
+
+	if piped(args[1:]) {
+		return forEachIdentifier(args[1:], func(args []string) error {
+			return executeActions(ctx, append([]string{command}, args...))
+		})
+	}
+
+	switch command {
+	case "runs":
+		return executeActionsRuns(ctx, args[1:])
+	case "logs":
+		return executeActionsLogs(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid actions command: '%s'", command))
+	}
+}
+
+type workflowRun = github.WorkflowRun
+
+func executeActionsRuns(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("actions runs", flag.ExitOnError)
+
+	workflow := flagSet.String("workflow", "", "only show runs of a workflow, by file name or id, e.g. ci.yml")
+	branch := flagSet.String("branch", "", "only show runs for a branch")
+	status := flagSet.String("status", "", "only show runs with a status or conclusion, e.g. failure or in_progress")
+	watch := flagSet.Bool("watch", false, "watch the most recent run until it completes")
+	interval := flagSet.Duration("interval", 10*time.Second, "how often to poll the run with --watch")
+	web := flagSet.Bool("web", false, "open the filtered runs in the browser")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: actions runs <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("actions runs", "repo", owner+"/"+name, "workflow", *workflow, "branch", *branch, "status", *status)
+
+	if *web {
+		return openBrowser(ctx, workflowRunsWebURL(owner, name, *workflow, *branch, *status))
+	}
+
+	limit := page.max()
+	if *watch {
+		limit = 1
+	}
+
+	pages := githubClient().ListWorkflowRuns(ctx, owner, name, &github.WorkflowRunOptions{
+		Workflow: *workflow,
+		Branch:   *branch,
+		Status:   *status,
+	})
+
+	runs, err := collectPages(ctx, pages, limit, nil)
+	if err != nil {
+		return err
+	}
+
+	if *watch {
+		if len(runs) == 0 {
+			return errors.New("no workflow runs found")
+		}
+
+		return watchWorkflowRun(ctx, owner, name, runs[0], *interval)
+	}
+
+	return printWorkflowRuns(runs)
+}
+
+// workflowRunsWebURL builds the url of the actions page of a repository with
+// the same filters as actions runs.
+func workflowRunsWebURL(owner, name, workflow, branch, status string) string {
+	u := fmt.Sprintf("https://github.com/%s/%s/actions", owner, name)
+	if workflow != "" {
+		u += "/workflows/" + workflow
+	}
+
+	terms := make([]string, 0)
+	if status != "" {
+		terms = append(terms, "is:"+status)
+	}
+	if branch != "" {
+		terms = append(terms, "branch:"+branch)
+	}
+
+	if len(terms) == 0 {
+		return u
+	}
+
+	query := url.Values{}
+	query.Set("query", strings.Join(terms, " "))
+
+	return u + "?" + query.Encode()
+}
+
+// watchWorkflowRun polls the run until it completed and fails when it did
+// not succeed, so it can be used to wait for ci in scripts.
+func watchWorkflowRun(ctx context.Context, owner, name string, run workflowRun, interval time.Duration) error {
+	for run.Status != "completed" {
+		fmt.Fprintf(os.Stderr, "%s %s #%d %s (%s)\n", time.Now().Format("15:04:05"), run.Name, run.ID, run.Status, run.Duration())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		latest, err := githubClient().GetWorkflowRun(ctx, owner, name, run.ID)
+		if err != nil {
+			return requestError(ctx, err)
+		}
+
+		run = *latest
+	}
+
+	err := printWorkflowRuns([]workflowRun{run})
+	if err != nil {
+		return err
+	}
+
+	if run.Conclusion != "success" {
+		return fmt.Errorf("run %d finished with %s: %s", run.ID, run.Conclusion, run.HTMLURL)
+	}
+
+	return nil
+}
+
+func printWorkflowRuns(runs []workflowRun) error {
+	if jsonOutput() {
+		return printJSON(runs)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tWORKFLOW\tBRANCH\tEVENT\tRESULT\tDURATION\tACTOR\tSTARTED")
+
+	for _, r := range runs {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s ago\n", r.ID, r.Name, r.HeadBranch, r.Event, r.Result(), r.Duration(), r.Actor.Login, formatAge(r.RunStartedAt))
+	}
+
+	return w.Flush()
+}
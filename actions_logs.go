@@ -0,0 +1,230 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+type workflowJob = github.WorkflowJob
+
+func executeActionsLogs(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("actions logs", flag.ExitOnError)
+
+	run := flagSet.Int64("run", 0, "id of the workflow run")
+	jobName := flagSet.String("job", "", "only show the logs of the job with this name")
+	failedOnly := flagSet.Bool("failed-only", false, "only show the logs of failed steps")
+	dir := flagSet.String("dir", "", "extract the log archive to a directory instead of printing it")
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) == 0 || *run == 0 {
+		return usageError("provide a repository and run: actions logs <owner/name> --run <id>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("actions logs", "repo", owner+"/"+name, "run", *run, "job", *jobName)
+
+	archive, err := os.CreateTemp("", "gcf-logs-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive.Name())
+
+	client := githubClient()
+
+	err = requestError(ctx, client.DownloadWorkflowRunLogs(ctx, owner, name, *run, archive))
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return fmt.Errorf("no logs found for run %d, they may have expired", *run)
+		}
+		return err
+	}
+
+	reader, err := zip.OpenReader(archive.Name())
+	if err != nil {
+		loggerFrom(ctx).Debug("opening the log archive failed", "err", err)
+		return errors.New("failed to read the log archive")
+	}
+	defer reader.Close()
+
+	if *dir != "" {
+		return extractLogs(ctx, reader, *dir)
+	}
+
+	jobs, err := collectPages(ctx, client.ListWorkflowJobs(ctx, owner, name, *run), 0, nil)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range reader.File {
+		files[f.Name] = f
+	}
+
+	color := colorOutput()
+	found := false
+
+	for _, job := range jobs {
+		if *jobName != "" && job.Name != *jobName {
+			continue
+		}
+		found = true
+
+		if *failedOnly && job.Conclusion != "failure" {
+			continue
+		}
+
+		for _, step := range job.Steps {
+			if *failedOnly && step.Conclusion != "failure" {
+				continue
+			}
+
+			file := findStepLog(files, job.Name, step.Number)
+			if file == nil {
+				loggerFrom(ctx).Debug("actions logs: no log for step", "job", job.Name, "step", step.Name)
+				continue
+			}
+
+			header := fmt.Sprintf("==> %s / %s (%s)", job.Name, step.Name, step.Conclusion)
+			if color {
+				header = ansiBold + conclusionColor(step.Conclusion) + header + ansiReset
+			}
+			fmt.Println(header)
+
+			err := printStepLog(file, color)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if *jobName != "" && !found {
+		return fmt.Errorf("run %d has no job named '%s'", *run, *jobName)
+	}
+
+	return nil
+}
+
+// findStepLog finds the log file of a step in the archive. GitHub replaces
+// or removes characters like "/" and ":" in the job directory names, so the
+// names are compared by their letters and digits only.
+func findStepLog(files map[string]*zip.File, job string, number int) *zip.File {
+	prefix := fmt.Sprintf("%d_", number)
+
+	for name, f := range files {
+		dir, base := path.Split(name)
+
+		if logName(dir) == logName(job) && strings.HasPrefix(base, prefix) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func logName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func conclusionColor(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return ansiGreen
+	case "failure", "cancelled", "timed_out":
+		return ansiRed
+	default:
+		return ansiYellow
+	}
+}
+
+// printStepLog prints a step log, highlighting the workflow commands that
+// the runner writes as "##[error]", "##[warning]" and "##[group]".
+func printStepLog(file *zip.File, color bool) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if !color {
+		_, err := io.Copy(os.Stdout, rc)
+		return err
+	}
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.Contains(line, "##[error]"):
+			line = ansiRed + line + ansiReset
+		case strings.Contains(line, "##[warning]"):
+			line = ansiYellow + line + ansiReset
+		case strings.Contains(line, "##[group]"):
+			line = ansiBold + line + ansiReset
+		}
+
+		fmt.Println(line)
+	}
+
+	return scanner.Err()
+}
+
+// extractLogs writes every log file of the archive to dir, keeping the job
+// directories.
+func extractLogs(ctx context.Context, reader *zip.ReadCloser, dir string) error {
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		dest := filepath.Join(dir, filepath.FromSlash(f.Name))
+
+		rel, err := filepath.Rel(dir, dest)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			loggerFrom(ctx).Debug("extract: skipping", "file", f.Name)
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeArchiveFile(dest, rc, 0o644)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(dir)
+
+	return nil
+}
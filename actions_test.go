@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchWorkflowRun(t *testing.T) {
+	tests := []struct {
+		name string
+		// polls are the states of the run github answers the polls with.
+		polls   []string
+		wantErr string
+	}{
+		{name: "succeeded", polls: []string{`"status": "in_progress"`, `"status": "completed", "conclusion": "success"`}},
+		{name: "failed", polls: []string{`"status": "completed", "conclusion": "failure"`}, wantErr: "run 7 finished with failure"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polled := 0
+
+			withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/repos/octocat/hello-world/actions/runs/7" {
+					t.Errorf("path = %s", req.URL.Path)
+				}
+
+				state := tt.polls[polled]
+				polled++
+
+				return respondJSON(req, `{"id": 7, "name": "ci", `+state+`}`), nil
+			}))
+
+			run := workflowRun{ID: 7, Name: "ci", Status: "queued"}
+
+			_, err := captureStdout(func() error {
+				return watchWorkflowRun(context.Background(), "octocat", "hello-world", run, time.Millisecond)
+			})
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+
+			if polled != len(tt.polls) {
+				t.Errorf("polled %d times, want %d", polled, len(tt.polls))
+			}
+		})
+	}
+}
+
+func TestWatchWorkflowRunStopsWhenCancelled(t *testing.T) {
+	withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("polled %s after the context was cancelled", req.URL.Path)
+		return respondJSON(req, `{}`), nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	run := workflowRun{ID: 7, Name: "ci", Status: "in_progress"}
+
+	err := watchWorkflowRun(ctx, "octocat", "hello-world", run, time.Hour)
+	if err == nil {
+		t.Error("watchWorkflowRun = nil, want the error of the cancelled context")
+	}
+}
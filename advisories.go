@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+type advisory = github.Advisory
+
+var advisoriesUsage = `Specify an advisories command to execute:
+  - search: Search the github advisory database`
+
+func executeAdvisories(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(advisoriesUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("advisories", "command", command)
+
+	switch command {
+	case "search":
+		return executeAdvisoriesSearch(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid advisories command: '%s'", command))
+	}
+}
+
+func executeAdvisoriesSearch(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("advisories search", flag.ExitOnError)
+
+	ecosystem := flagSet.String("ecosystem", "", "only show advisories for an ecosystem, e.g. go, npm or pip")
+	severity := flagSet.String("severity", "", "only show advisories of a severity: low, medium, high or critical")
+	affects := flagSet.String("affects", "", "only show advisories affecting a package")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+
+	opts := &github.AdvisoryOptions{
+		Ecosystem: *ecosystem,
+		Severity:  *severity,
+		Affects:   *affects,
+	}
+	if len(args) > 0 {
+		opts.CVEID = args[0]
+	}
+
+	loggerFrom(ctx).Debug("advisories search", "ecosystem", opts.Ecosystem, "severity", opts.Severity, "affects", opts.Affects, "cve", opts.CVEID)
+
+	advisories, err := collectPages(ctx, githubClient().ListAdvisories(ctx, opts), page.max(), nil)
+	if err != nil {
+		return err
+	}
+
+	return printAdvisories(advisories)
+}
+
+func executeRepoAdvisories(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo advisories", flag.ExitOnError)
+
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo advisories <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo advisories", "repo", owner+"/"+name)
+
+	advisories, err := collectPages(ctx, githubClient().ListRepositoryAdvisories(ctx, owner, name), page.max(), nil)
+	if err != nil {
+		return err
+	}
+
+	return printAdvisories(advisories)
+}
+
+func printAdvisories(advisories []advisory) error {
+	if jsonOutput() {
+		return printJSON(advisories)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GHSA\tSEVERITY\tPACKAGE\tAFFECTED\tPATCHED")
+
+	// An advisory gets a row for every affected package.
+	for _, a := range advisories {
+		if len(a.Vulnerabilities) == 0 {
+			fmt.Fprintf(w, "%s\t%s\t-\t-\t-\n", a.GHSAID, a.Severity)
+			continue
+		}
+
+		for _, v := range a.Vulnerabilities {
+			pkg := strings.Trim(v.Package.Ecosystem+"/"+v.Package.Name, "/")
+
+			patched := v.PatchedVersions
+			if patched == "" {
+				patched = "-"
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.GHSAID, a.Severity, pkg, v.VulnerableVersionRange, patched)
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultAlertTemplate is the message posted for new results when no
+// --alert-template is given.
+const defaultAlertTemplate = `{{.Count}} new {{if eq .Count 1}}repository{{else}}repositories{{end}} for {{.Term}}
+{{range .Repositories}}- {{.FullName}} ({{.StargazersCount}} stars) {{.HTMLURL}}{{if .Description}}
+  {{truncate .Description 100}}{{end}}
+{{end}}`
+
+// discordMessageLimit is the longest content discord accepts.
+const discordMessageLimit = 2000
+
+// alertData is what alert templates are executed with.
+type alertData struct {
+	Term         string
+	Count        int
+	Repositories []repository
+}
+
+// alerter posts the new results of watched and diffed searches to a slack,
+// discord or any other incoming webhook.
+type alerter struct {
+	url      string
+	template *template.Template
+	client   *http.Client
+}
+
+// newAlerter parses the message template, text falls back to the default
+// one. It returns nil without an url so callers can skip alerting.
+func newAlerter(alertURL, text string) (*alerter, error) {
+	if alertURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(alertURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, usageErrorf("invalid alert url '%s'", alertURL)
+	}
+
+	if text == "" {
+		text = defaultAlertTemplate
+	}
+
+	tmpl, err := template.New("alert").Funcs(template.FuncMap{"truncate": truncate}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert template: %v", err)
+	}
+
+	return &alerter{url: alertURL, template: tmpl, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// alertTemplate returns the inline template or the one read from file.
+func alertTemplate(text, file string) (string, error) {
+	if file == "" {
+		return text, nil
+	}
+
+	if text != "" {
+		return "", errors.New("use either --alert-template or --alert-template-file")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// send posts the repositories added to the results, if any. Failures are
+// reported on stderr and do not end the search.
+func (a *alerter) send(ctx context.Context, term string, changes []repositoryChange) {
+	if a == nil {
+		return
+	}
+
+	data := alertData{Term: term}
+
+	for _, c := range changes {
+		if c.Change == "added" {
+			data.Repositories = append(data.Repositories, c.Repository)
+		}
+	}
+
+	data.Count = len(data.Repositories)
+	if data.Count == 0 {
+		return
+	}
+
+	err := a.post(ctx, data)
+	if err != nil {
+		loggerFrom(ctx).Warn("sending the alert failed", "err", err)
+	}
+}
+
+func (a *alerter) post(ctx context.Context, data alertData) error {
+	var message bytes.Buffer
+
+	err := a.template.Execute(&message, data)
+	if err != nil {
+		return err
+	}
+
+	text := strings.TrimSpace(message.String())
+
+	// Slack and discord take the message under different keys, other
+	// webhooks get the results too.
+	var payload interface{}
+
+	switch u, _ := url.Parse(a.url); {
+	case u.Host == "hooks.slack.com":
+		payload = map[string]string{"text": text}
+	case (u.Host == "discord.com" || u.Host == "discordapp.com") && strings.HasPrefix(u.Path, "/api/webhooks/"):
+		payload = map[string]string{"content": truncate(text, discordMessageLimit)}
+	default:
+		payload = map[string]interface{}{
+			"text":         text,
+			"term":         data.Term,
+			"count":        data.Count,
+			"repositories": data.Repositories,
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("alert: posting", "repositories", data.Count, "url", redactURL(a.url))
+
+	res, err := a.client.Post(a.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post to %s", redactURL(a.url))
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s answered %s", redactURL(a.url), res.Status)
+	}
+
+	return nil
+}
+
+// redactURL leaves the path out of webhook urls, which carries their secret.
+func redactURL(alertURL string) string {
+	u, err := url.Parse(alertURL)
+	if err != nil {
+		return "the alert url"
+	}
+
+	return u.Scheme + "://" + u.Host
+}
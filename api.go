@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var apiUsage = `Usage: api <endpoint> [flags]
+
+Sends a request to the github api with the token of the environment and
+prints the response, e.g. api /repos/{owner}/{repo}/issues --field title=Bug.
+{owner} and {repo} are filled in from --repo or the origin remote of the git
+repository in the current directory.`
+
+func executeAPI(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("api", flag.ExitOnError)
+
+	method := flagSet.String("method", "", "http method, defaults to GET, or POST when fields are given")
+	fields := stringList{}
+	rawFields := stringList{}
+	headers := stringList{}
+	flagSet.Var(&fields, "field", "parameter as key=value, true, false, null and numbers are sent as such and @file reads the value from a file, can be repeated")
+	flagSet.Var(&rawFields, "raw-field", "string parameter as key=value, can be repeated")
+	flagSet.Var(&headers, "header", "request header as key:value, can be repeated")
+	input := flagSet.String("input", "", "file to send as the request body, - reads stdin")
+	paginate := flagSet.Bool("paginate", false, "fetch every page and print the results together")
+	include := flagSet.Bool("include", false, "print the status and headers of the response")
+	repo := flagSet.String("repo", "", "repository filling in {owner} and {repo}, defaults to the origin remote")
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) == 0 {
+		return usageError(apiUsage)
+	}
+
+	endpoint, err := expandEndpoint(args[0], *repo)
+	if err != nil {
+		return err
+	}
+
+	params, err := apiParams(fields, rawFields)
+	if err != nil {
+		return err
+	}
+
+	if *method == "" {
+		*method = http.MethodGet
+		if len(params) > 0 || *input != "" {
+			*method = http.MethodPost
+		}
+	}
+	*method = strings.ToUpper(*method)
+
+	loggerFrom(ctx).Debug("api", "method", *method, "endpoint", endpoint, "params", params, "paginate", *paginate)
+
+	// Fields are the query of GET requests and the json body of the others.
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return usageErrorf("invalid endpoint '%s'", args[0])
+	}
+
+	query := u.Query()
+
+	var body []byte
+
+	switch {
+	case *input != "":
+		body, err = readInput(*input)
+		if err != nil {
+			return err
+		}
+
+		for key, value := range params {
+			query.Set(key, fmt.Sprint(value))
+		}
+	case *method == http.MethodGet || *method == http.MethodHead:
+		for key, value := range params {
+			query.Set(key, fmt.Sprint(value))
+		}
+	case len(params) > 0:
+		body, err = json.Marshal(params)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Next pages are requested with the query of their link as is.
+	path, rawQuery := u.Path, query.Encode()
+
+	var pages []json.RawMessage
+
+	for {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := newGithubRequest(ctx, *method, path, nil, reader)
+		if err != nil {
+			return err
+		}
+
+		req.URL.RawQuery = rawQuery
+
+		for _, header := range headers {
+			key, value, ok := strings.Cut(header, ":")
+			if !ok {
+				return usageErrorf("invalid header '%s', expected key:value", header)
+			}
+			req.Header.Set(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+
+		res, data, err := sendAPIRequest(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if *include {
+			printResponseHeaders(res)
+		}
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			printAPIBody(data)
+
+			// The body was read already, the error is built from the copy.
+			res.Body = io.NopCloser(bytes.NewReader(data))
+
+			return responseError(ctx, res)
+		}
+
+		next := github.NextPageURL(res.Header)
+
+		if !*paginate || next == "" {
+			if len(pages) == 0 {
+				printAPIBody(data)
+				return nil
+			}
+
+			return printAPIPages(append(pages, data))
+		}
+
+		pages = append(pages, data)
+
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			return fmt.Errorf("invalid next page url '%s'", next)
+		}
+
+		path, rawQuery = nextURL.Path, nextURL.RawQuery
+	}
+}
+
+// expandEndpoint fills in the {owner} and {repo} placeholders and strips the
+// api root from full urls.
+func expandEndpoint(endpoint, repo string) (string, error) {
+	endpoint = strings.TrimPrefix(endpoint, githubAPI)
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+
+	if !strings.Contains(endpoint, "{owner}") && !strings.Contains(endpoint, "{repo}") {
+		return endpoint, nil
+	}
+
+	if repo == "" {
+		var err error
+		repo, err = originRepo()
+		if err != nil {
+			return "", usageError("provide a repository for {owner} and {repo}: api <endpoint> --repo <owner/name>")
+		}
+	}
+
+	owner, name, err := parseRepoName(repo)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint = strings.ReplaceAll(endpoint, "{owner}", owner)
+	endpoint = strings.ReplaceAll(endpoint, "{repo}", name)
+
+	return endpoint, nil
+}
+
+// apiParams parses the --field and --raw-field flags. Keys ending in []
+// collect their values in an array, e.g. --field labels[]=bug.
+func apiParams(fields, rawFields []string) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+
+	set := func(field string, typed bool) error {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return usageErrorf("invalid field '%s', expected key=value", field)
+		}
+
+		var v interface{} = value
+
+		if typed {
+			switch {
+			case value == "true":
+				v = true
+			case value == "false":
+				v = false
+			case value == "null":
+				v = nil
+			case strings.HasPrefix(value, "@"):
+				data, err := readInput(value[1:])
+				if err != nil {
+					return err
+				}
+				v = string(data)
+			default:
+				if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+					v = n
+				}
+			}
+		}
+
+		if name, isArray := strings.CutSuffix(key, "[]"); isArray {
+			values, _ := params[name].([]interface{})
+			params[name] = append(values, v)
+			return nil
+		}
+
+		params[key] = v
+
+		return nil
+	}
+
+	for _, field := range fields {
+		if err := set(field, true); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, field := range rawFields {
+		if err := set(field, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return params, nil
+}
+
+// readInput reads the file at path, "-" reads stdin.
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+// sendAPIRequest sends the request through the transport of the other
+// commands and returns the response with its whole body, whatever the
+// status.
+func sendAPIRequest(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	if *offline {
+		return nil, nil, errOffline
+	}
+
+	spin := startSpinner(req.Method + " " + req.URL.Path)
+	defer spin.Stop()
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, networkError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, networkError(ctx, err)
+	}
+
+	return res, data, nil
+}
+
+func printResponseHeaders(res *http.Response) {
+	fmt.Printf("%s %s\n", res.Proto, res.Status)
+
+	keys := make([]string, 0, len(res.Header))
+	for key := range res.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range res.Header[key] {
+			fmt.Printf("%s: %s\n", key, value)
+		}
+	}
+
+	fmt.Println()
+}
+
+// printAPIBody pretty prints json bodies and prints anything else as is.
+func printAPIBody(data []byte) {
+	var indented bytes.Buffer
+
+	if json.Indent(&indented, data, "", "  ") == nil {
+		fmt.Println(indented.String())
+		return
+	}
+
+	os.Stdout.Write(data)
+}
+
+// printAPIPages prints the pages of a paginated request as one json array
+// when they are arrays, like most list endpoints, or one after another.
+func printAPIPages(pages []json.RawMessage) error {
+	items := make([]json.RawMessage, 0)
+
+	for _, page := range pages {
+		var pageItems []json.RawMessage
+
+		if json.Unmarshal(page, &pageItems) != nil {
+			for _, page := range pages {
+				printAPIBody(page)
+			}
+
+			return nil
+		}
+
+		items = append(items, pageItems...)
+	}
+
+	return printJSON(items)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// extractTarGz extracts a gzipped tar archive into dir. Github archives put
+// everything into a single top level directory named after the commit, it is
+// stripped so the files land directly in dir.
+func extractTarGz(ctx context.Context, archive, dir string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	reader := tar.NewReader(gz)
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		dest, ok := archiveDest(ctx, dir, header.Name)
+		if !ok {
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(dest, 0o755)
+		case tar.TypeReg:
+			err = writeArchiveFile(dest, reader, os.FileMode(header.Mode))
+		case tar.TypeSymlink:
+			if !safeLink(dir, dest, header.Linkname) {
+				loggerFrom(ctx).Warn("extract: skipping a link out of the directory", "file", header.Name, "target", header.Linkname)
+				continue
+			}
+
+			err = os.Symlink(header.Linkname, dest)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// extractZip extracts a zip archive into dir, stripping the top level
+// directory like extractTarGz.
+func extractZip(ctx context.Context, archive, dir string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, f := range reader.File {
+		dest, ok := archiveDest(ctx, dir, f.Name)
+		if !ok {
+			continue
+		}
+
+		if f.FileInfo().IsDir() {
+			err := os.MkdirAll(dest, 0o755)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeArchiveFile(dest, rc, f.Mode())
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archiveDest returns where an archive entry is extracted to in dir. It
+// reports false for the top level directory itself, for entries that would
+// escape dir and for entries under a link, which could lead anywhere.
+func archiveDest(ctx context.Context, dir, name string) (string, bool) {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) < 2 || parts[1] == "" {
+		return "", false
+	}
+
+	dest := filepath.Join(dir, filepath.FromSlash(parts[1]))
+
+	if !within(dir, dest) || underLink(dir, dest) {
+		loggerFrom(ctx).Debug("extract: skipping", "file", name)
+		return "", false
+	}
+
+	return dest, true
+}
+
+// within reports whether path is dir or inside it.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// underLink reports whether dest or a directory between dir and it is a
+// link, extracted before or there already.
+func underLink(dir, dest string) bool {
+	rel, err := filepath.Rel(dir, dest)
+	if err != nil || rel == "." {
+		return false
+	}
+
+	path := dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		path = filepath.Join(path, part)
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return false
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// safeLink reports whether a link at dest to target stays in dir. Absolute
+// targets and relative ones climbing out of dir do not.
+func safeLink(dir, dest, target string) bool {
+	if target == "" || filepath.IsAbs(target) || filepath.VolumeName(target) != "" {
+		return false
+	}
+
+	return within(dir, filepath.Join(filepath.Dir(dest), filepath.FromSlash(target)))
+}
+
+func writeArchiveFile(dest string, r io.Reader, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(dest), 0o755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchiveDest(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.Symlink(".", filepath.Join(dir, "here"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{name: "golang-go-1a2b3c/README.md", want: "README.md", ok: true},
+		{name: "golang-go-1a2b3c/src/cmd/go/main.go", want: "src/cmd/go/main.go", ok: true},
+		{name: "golang-go-1a2b3c/src/", want: "src", ok: true},
+		{name: "golang-go-1a2b3c/..hidden", want: "..hidden", ok: true},
+		{name: "golang-go-1a2b3c/src/../README.md", want: "README.md", ok: true},
+		{name: "golang-go-1a2b3c/"},
+		{name: "golang-go-1a2b3c"},
+		{name: "golang-go-1a2b3c/../outside"},
+		{name: "golang-go-1a2b3c/src/../../outside"},
+		{name: "golang-go-1a2b3c/.."},
+		// Under a link, or the link itself.
+		{name: "golang-go-1a2b3c/here/README.md"},
+		{name: "golang-go-1a2b3c/here"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest, ok := archiveDest(context.Background(), dir, tt.name)
+
+			want := ""
+			if tt.ok {
+				want = filepath.Join(dir, filepath.FromSlash(tt.want))
+			}
+
+			if ok != tt.ok || dest != want {
+				t.Errorf("archiveDest = %q, %t, want %q, %t", dest, ok, want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSafeLink(t *testing.T) {
+	dir := filepath.Join("extracted", "repo")
+
+	tests := []struct {
+		dest   string
+		target string
+		want   bool
+	}{
+		{dest: "LICENSE.md", target: "LICENSE", want: true},
+		{dest: "docs/README.md", target: "../README.md", want: true},
+		{dest: "docs/current", target: "v2", want: true},
+		{dest: "docs/a/b/c", target: "../../../d", want: true},
+		{dest: "self", target: ".", want: true},
+		{dest: "etc", target: "/etc"},
+		{dest: "up", target: ".."},
+		{dest: "docs/up", target: "../../repo-other"},
+		{dest: "docs/a/escape", target: "../../../secrets"},
+		{dest: "empty", target: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dest+" -> "+tt.target, func(t *testing.T) {
+			if got := safeLink(dir, filepath.Join(dir, filepath.FromSlash(tt.dest)), tt.target); got != tt.want {
+				t.Errorf("safeLink = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTarGzLinks(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "repo")
+	archive := filepath.Join(root, "repo.tar.gz")
+
+	writeTarGz(t, archive, []tar.Header{
+		{Name: "octo-repo-1a2b3c/", Typeflag: tar.TypeDir},
+		{Name: "octo-repo-1a2b3c/docs/", Typeflag: tar.TypeDir},
+		{Name: "octo-repo-1a2b3c/README.md", Typeflag: tar.TypeReg},
+		{Name: "octo-repo-1a2b3c/docs/README.md", Typeflag: tar.TypeSymlink, Linkname: "../README.md"},
+		// Links out of the directory are skipped, so are the entries that
+		// would be written through them.
+		{Name: "octo-repo-1a2b3c/absolute", Typeflag: tar.TypeSymlink, Linkname: root},
+		{Name: "octo-repo-1a2b3c/absolute/evil", Typeflag: tar.TypeReg},
+		{Name: "octo-repo-1a2b3c/parent", Typeflag: tar.TypeSymlink, Linkname: ".."},
+		{Name: "octo-repo-1a2b3c/parent/evil", Typeflag: tar.TypeReg},
+		// Each link stays in the directory, but the second leads out through
+		// the first.
+		{Name: "octo-repo-1a2b3c/here", Typeflag: tar.TypeSymlink, Linkname: "."},
+		{Name: "octo-repo-1a2b3c/chained", Typeflag: tar.TypeSymlink, Linkname: "here/.."},
+		{Name: "octo-repo-1a2b3c/chained/evil", Typeflag: tar.TypeReg},
+		{Name: "octo-repo-1a2b3c/chained", Typeflag: tar.TypeReg},
+	})
+
+	err := extractTarGz(context.Background(), archive, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "evil")); err == nil {
+		t.Error("an entry was written out of the directory")
+	}
+
+	for _, name := range []string{"absolute", "parent"} {
+		if info, err := os.Lstat(filepath.Join(dir, name)); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			t.Errorf("the link %s out of the directory was extracted", name)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "docs", "README.md"))
+	if err != nil || string(data) != "octo-repo-1a2b3c/README.md" {
+		t.Errorf("docs/README.md = %q, %v", data, err)
+	}
+
+	if target, err := os.Readlink(filepath.Join(dir, "chained")); err != nil || target != "here/.." {
+		t.Errorf("chained was replaced, %q, %v", target, err)
+	}
+}
+
+// writeTarGz writes an archive of the entries, regular files contain their
+// name.
+func writeTarGz(t *testing.T, path string, entries []tar.Header) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	for _, header := range entries {
+		header.Mode = 0o644
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(header.Name))
+		}
+
+		err := tw.WriteHeader(&header)
+		if err == nil && header.Typeflag == tar.TypeReg {
+			_, err = tw.Write([]byte(header.Name))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
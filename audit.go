@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+var auditUsage = `Specify an audit command to execute:
+  - list: List the recorded changes made on github
+
+Changes are recorded with "audit": true in the config.`
+
+// auditEntry is a change made on github, like a star, a created issue or a
+// deleted repository.
+type auditEntry struct {
+	Time      time.Time `json:"time"`
+	Command   string    `json:"command"`
+	RequestID string    `json:"request_id"`
+	Method    string    `json:"method"`
+	Target    string    `json:"target"`
+	Status    int       `json:"status,omitempty"`
+	Result    string    `json:"result"`
+}
+
+func auditPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "audit.jsonl"), nil
+}
+
+// auditTransport appends every request changing something on github to the
+// audit log, whatever command sent it. The log is only ever appended to.
+type auditTransport struct {
+	mu      sync.Mutex
+	command string
+	log     *slog.Logger
+	next    http.RoundTripper
+}
+
+// auditHTTP installs the audit transport on the default http client when
+// the audit log is turned on in the config.
+func auditHTTP(ctx context.Context, command string) {
+	cfg, err := loadConfig(ctx)
+	if err != nil || !cfg.Audit {
+		return
+	}
+
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	http.DefaultClient.Transport = &auditTransport{command: command, log: loggerFrom(ctx), next: next}
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !mutating(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	entry := auditEntry{
+		Time:      time.Now(),
+		Command:   t.command,
+		RequestID: requestID,
+		Method:    req.Method,
+		Target:    auditTarget(req),
+	}
+
+	res, err := t.next.RoundTrip(req)
+
+	switch {
+	case err != nil:
+		entry.Result = "failed: " + err.Error()
+	case res.StatusCode >= 400:
+		entry.Status, entry.Result = res.StatusCode, "failed: "+res.Status
+	default:
+		entry.Status, entry.Result = res.StatusCode, "ok"
+	}
+
+	t.record(entry)
+
+	return res, err
+}
+
+// mutating reports whether a request changes something. Graphql requests
+// are posted for queries too, only mutations count.
+func mutating(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+
+	if req.URL.Path != "/graphql" {
+		return true
+	}
+
+	op, ok := graphqlRequestOperation(req)
+
+	// A request that can not be told apart from a mutation is recorded.
+	return !ok || op.kind == "mutation"
+}
+
+// auditTarget is what the entry of a request is about, the path, followed by
+// the name of the operation for graphql.
+func auditTarget(req *http.Request) string {
+	if req.URL.Path != "/graphql" {
+		return req.URL.Path
+	}
+
+	op, ok := graphqlRequestOperation(req)
+	if !ok || op.name == "" {
+		return req.URL.Path
+	}
+
+	return req.URL.Path + " " + op.name
+}
+
+// graphqlOperation is an operation of a graphql document, kind is query,
+// mutation or subscription.
+type graphqlOperation struct {
+	kind string
+	name string
+}
+
+var (
+	graphqlComment = regexp.MustCompile(`#[^\n]*`)
+	graphqlToken   = regexp.MustCompile(`[_A-Za-z][_0-9A-Za-z]*|[{}()]`)
+)
+
+// graphqlRequestOperation returns the operation the body of req runs, the
+// one named by its operationName or the first.
+func graphqlRequestOperation(req *http.Request) (graphqlOperation, bool) {
+	if req.GetBody == nil {
+		return graphqlOperation{}, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return graphqlOperation{}, false
+	}
+	defer body.Close()
+
+	payload := struct {
+		Query         string `json:"query"`
+		OperationName string `json:"operationName"`
+	}{}
+
+	err = json.NewDecoder(body).Decode(&payload)
+	if err != nil {
+		return graphqlOperation{}, false
+	}
+
+	ops := parseGraphQLOperations(payload.Query)
+	if len(ops) == 0 {
+		return graphqlOperation{}, false
+	}
+
+	for _, op := range ops {
+		if payload.OperationName != "" && op.name == payload.OperationName {
+			return op, true
+		}
+	}
+
+	return ops[0], true
+}
+
+// parseGraphQLOperations returns the operations of a graphql document by the
+// first keyword of each definition, fragments are left out. Anonymous
+// operations are named after their first field, like addStar.
+func parseGraphQLOperations(query string) []graphqlOperation {
+	tokens := graphqlToken.FindAllString(graphqlComment.ReplaceAllString(query, ""), -1)
+
+	ops := make([]graphqlOperation, 0)
+
+	for i := 0; i < len(tokens); {
+		op := graphqlOperation{}
+
+		switch tokens[i] {
+		case "{":
+			// The shorthand of a query, { viewer { login } }.
+			op.kind = "query"
+		case "query", "mutation", "subscription":
+			op.kind = tokens[i]
+			if i+1 < len(tokens) && !strings.ContainsAny(tokens[i+1], "{}()") {
+				op.name = tokens[i+1]
+			}
+		}
+
+		// Skip to the end of the definition, the first name in its
+		// outermost selection set is its first field.
+		field := ""
+		braces, parens := 0, 0
+
+		for ; i < len(tokens); i++ {
+			switch tokens[i] {
+			case "{":
+				braces++
+			case "}":
+				braces--
+			case "(":
+				parens++
+			case ")":
+				parens--
+			default:
+				if field == "" && braces == 1 && parens == 0 {
+					field = tokens[i]
+				}
+			}
+
+			if tokens[i] == "}" && braces == 0 {
+				i++
+				break
+			}
+		}
+
+		if op.kind == "" {
+			continue
+		}
+
+		if op.name == "" {
+			op.name = field
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops
+}
+
+// record appends the entry to the audit log. Failing to record is reported
+// but does not fail the command, the change is made already.
+func (t *auditTransport) record(entry auditEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	path, err := auditPath()
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o700)
+	}
+
+	var file *os.File
+	if err == nil {
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	}
+
+	if err == nil {
+		err = json.NewEncoder(file).Encode(entry)
+
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if err != nil {
+		t.log.Warn("recording the audit log failed", "method", entry.Method, "target", entry.Target, "err", err)
+	}
+}
+
+func readAudit() ([]auditEntry, error) {
+	path, err := auditPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]auditEntry, 0)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		entry := auditEntry{}
+
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+func executeAudit(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(auditUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("audit", "command", command)
+
+	switch command {
+	case "list":
+		return executeAuditList(args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid audit command: '%s'", command))
+	}
+}
+
+func executeAuditList(args []string) error {
+	flagSet := flag.NewFlagSet("audit list", flag.ExitOnError)
+
+	limit := flagSet.Int("limit", 30, "number of most recent entries to show, 0 shows all")
+	since := flagSet.Duration("since", 0, "only show the entries of this long ago or later, e.g. 24h")
+	target := flagSet.String("target", "", "only show the entries with a target containing this, e.g. a repository")
+	failed := flagSet.Bool("failed", false, "only show the failed changes")
+
+	parseArgs(flagSet, args)
+
+	entries, err := readAudit()
+	if err != nil {
+		return err
+	}
+
+	matches := make([]auditEntry, 0)
+
+	for _, e := range entries {
+		if *since > 0 && time.Since(e.Time) > *since {
+			continue
+		}
+		if *target != "" && !strings.Contains(strings.ToLower(e.Target), strings.ToLower(*target)) {
+			continue
+		}
+		if *failed && e.Result == "ok" {
+			continue
+		}
+
+		matches = append(matches, e)
+	}
+
+	if *limit > 0 && len(matches) > *limit {
+		matches = matches[len(matches)-*limit:]
+	}
+
+	if jsonOutput() {
+		return printJSON(matches)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "TIME\tCOMMAND\tMETHOD\tTARGET\tRESULT")
+
+	for _, e := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Time.Local().Format("2006-01-02 15:04"), e.Command, e.Method, e.Target, e.Result)
+	}
+
+	return w.Flush()
+}
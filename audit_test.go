@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestMutating(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		// once gives the request a body that cannot be read again.
+		once bool
+		want bool
+	}{
+		{name: "get", method: http.MethodGet, path: "/repos/golang/go", want: false},
+		{name: "head", method: http.MethodHead, path: "/repos/golang/go", want: false},
+		{name: "post", method: http.MethodPost, path: "/repos/golang/go/issues", body: `{"title":"crash"}`, want: true},
+		{name: "put", method: http.MethodPut, path: "/user/starred/golang/go", want: true},
+		{name: "patch", method: http.MethodPatch, path: "/notifications/threads/1", want: true},
+		{name: "delete", method: http.MethodDelete, path: "/repos/octo/old", want: true},
+		{name: "graphql query", method: http.MethodPost, path: "/graphql", body: `{"query":"query { viewer { login } }"}`, want: false},
+		{name: "graphql mutation", method: http.MethodPost, path: "/graphql", body: `{"query":"mutation { addStar }"}`, want: true},
+		{name: "graphql without GetBody", method: http.MethodPost, path: "/graphql", body: `{"query":"query { viewer { login } }"}`, once: true, want: true},
+		{name: "graphql query shorthand", method: http.MethodPost, path: "/graphql", body: `{"query":"{ viewer { login } }"}`, want: false},
+		{name: "graphql query mentioning mutation", method: http.MethodPost, path: "/graphql", body: `{"query":"query($q: String!) { search(query: $q, type: REPOSITORY) { repositoryCount } }","variables":{"q":"mutation testing"}}`, want: false},
+		{name: "graphql query of a mutation field", method: http.MethodPost, path: "/graphql", body: `{"query":"# no mutation here\nquery { mutationTesting: viewer { login } }"}`, want: false},
+		{name: "named graphql mutation", method: http.MethodPost, path: "/graphql", body: `{"query":"mutation AddStar($id: ID!) { addStar(input: {starrableId: $id}) { clientMutationId } }"}`, want: true},
+		{name: "graphql mutation after a fragment", method: http.MethodPost, path: "/graphql", body: `{"query":"fragment f on Repository { id } mutation { addStar }"}`, want: true},
+		{name: "graphql mutation by operation name", method: http.MethodPost, path: "/graphql", body: `{"query":"query Viewer { viewer { login } } mutation Star { addStar }","operationName":"Star"}`, want: true},
+		{name: "invalid graphql body", method: http.MethodPost, path: "/graphql", body: `mutation`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testRequest(t, tt.method, tt.path, tt.body)
+			if tt.once {
+				req.Body = io.NopCloser(req.Body)
+				req.GetBody = nil
+			}
+
+			if got := mutating(req); got != tt.want {
+				t.Errorf("mutating = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+		want string
+	}{
+		{name: "rest", path: "/user/starred/golang/go", want: "/user/starred/golang/go"},
+		{name: "named mutation", path: "/graphql", body: `{"query":"mutation AddStar($id: ID!) { addStar(input: {starrableId: $id}) { clientMutationId } }"}`, want: "/graphql AddStar"},
+		{name: "anonymous mutation", path: "/graphql", body: `{"query":"mutation($id: ID!) {\n  removeStar(input: {starrableId: $id}) { clientMutationId }\n}"}`, want: "/graphql removeStar"},
+		{name: "invalid body", path: "/graphql", body: `mutation`, want: "/graphql"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testRequest(t, http.MethodPost, tt.path, tt.body)
+
+			if got := auditTarget(req); got != tt.want {
+				t.Errorf("auditTarget = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
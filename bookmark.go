@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var bookmarkUsage = `Specify a bookmark command to execute:
+  - add: Bookmark repositories or users
+  - list: List the bookmarks
+  - remove: Remove bookmarks`
+
+// bookmark is a saved repository, owner/name, or user, login.
+type bookmark struct {
+	Name    string    `json:"name"`
+	Kind    string    `json:"kind"`
+	Tags    []string  `json:"tags,omitempty"`
+	Note    string    `json:"note,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+func bookmarksPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "bookmarks.json"), nil
+}
+
+func loadBookmarks() ([]bookmark, error) {
+	path, err := bookmarksPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []bookmark{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]bookmark, 0)
+
+	err = json.Unmarshal(data, &bookmarks)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bookmarks file %s: %v", path, err)
+	}
+
+	return bookmarks, nil
+}
+
+// saveBookmarks writes the bookmarks to a temporary file first so an
+// interrupted write does not lose them.
+func saveBookmarks(bookmarks []bookmark) error {
+	path, err := bookmarksPath()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path+".tmp", data, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// addBookmark adds name to the bookmarks, or updates it when it is already
+// bookmarked: the tags are merged and a non empty note replaces the old one.
+func addBookmark(name string, tags []string, note string) (bookmark, error) {
+	kind := "user"
+	if strings.Contains(name, "/") {
+		_, _, err := parseRepoName(name)
+		if err != nil {
+			return bookmark{}, err
+		}
+		kind = "repo"
+	}
+
+	bookmarks, err := loadBookmarks()
+	if err != nil {
+		return bookmark{}, err
+	}
+
+	for i, b := range bookmarks {
+		if !strings.EqualFold(b.Name, name) {
+			continue
+		}
+
+		for _, t := range tags {
+			if !containsString(b.Tags, t) {
+				b.Tags = append(b.Tags, t)
+			}
+		}
+
+		if note != "" {
+			b.Note = note
+		}
+
+		bookmarks[i] = b
+
+		return b, saveBookmarks(bookmarks)
+	}
+
+	b := bookmark{
+		Name:    name,
+		Kind:    kind,
+		Tags:    tags,
+		Note:    note,
+		AddedAt: time.Now(),
+	}
+
+	return b, saveBookmarks(append(bookmarks, b))
+}
+
+func executeBookmark(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(bookmarkUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("bookmark", "command", command)
+
+	switch command {
+	case "add":
+		return executeBookmarkAdd(args[1:])
+	case "list":
+		return executeBookmarkList(args[1:])
+	case "remove":
+		return executeBookmarkRemove(args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid bookmark command: '%s'", command))
+	}
+}
+
+func executeBookmarkAdd(args []string) error {
+	flagSet := flag.NewFlagSet("bookmark add", flag.ExitOnError)
+
+	tags := stringList{}
+	flagSet.Var(&tags, "tag", "tag to add to the bookmark, can be repeated")
+	note := flagSet.String("note", "", "note to keep with the bookmark")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name) or user")
+
+	if len(args) == 0 {
+		return usageError("provide a repository or user: bookmark add <owner/name|login>...")
+	}
+
+	for _, name := range args {
+		b, err := addBookmark(name, tags, *note)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Bookmarked %s %s\n", b.Kind, b.Name)
+	}
+
+	return nil
+}
+
+func executeBookmarkList(args []string) error {
+	flagSet := flag.NewFlagSet("bookmark list", flag.ExitOnError)
+
+	tag := flagSet.String("tag", "", "only list bookmarks with a tag")
+	kind := flagSet.String("kind", "", "only list bookmarks of a kind: repo or user")
+
+	parseArgs(flagSet, args)
+
+	bookmarks, err := loadBookmarks()
+	if err != nil {
+		return err
+	}
+
+	filtered := make([]bookmark, 0)
+
+	for _, b := range bookmarks {
+		if *tag != "" && !containsString(b.Tags, *tag) || *kind != "" && b.Kind != *kind {
+			continue
+		}
+
+		filtered = append(filtered, b)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].AddedAt.After(filtered[j].AddedAt)
+	})
+
+	if jsonOutput() {
+		return printJSON(filtered)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tKIND\tTAGS\tNOTE\tADDED")
+
+	for _, b := range filtered {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s ago\n", b.Name, b.Kind, strings.Join(b.Tags, ", "), truncate(b.Note, 50), formatAge(b.AddedAt))
+	}
+
+	return w.Flush()
+}
+
+func executeBookmarkRemove(args []string) error {
+	flagSet := flag.NewFlagSet("bookmark remove", flag.ExitOnError)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Bookmark")
+
+	if len(args) == 0 {
+		return usageError("provide a bookmark: bookmark remove <owner/name|login>...")
+	}
+
+	bookmarks, err := loadBookmarks()
+	if err != nil {
+		return err
+	}
+
+	kept := make([]bookmark, 0)
+
+	for _, b := range bookmarks {
+		removed := false
+
+		for _, name := range args {
+			if strings.EqualFold(b.Name, name) {
+				removed = true
+			}
+		}
+
+		if removed {
+			fmt.Printf("Removed %s\n", b.Name)
+			continue
+		}
+
+		kept = append(kept, b)
+	}
+
+	if len(kept) == len(bookmarks) {
+		return fmt.Errorf("no bookmark named %s", strings.Join(args, ", "))
+	}
+
+	return saveBookmarks(kept)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+)
+
+// openBrowser opens url in the default browser of the platform. When no
+// browser can be started the url is printed instead so it can be opened by
+// hand.
+func openBrowser(ctx context.Context, url string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	loggerFrom(ctx).Debug("browser: opening", "url", url)
+
+	err := cmd.Start()
+	if err != nil {
+		loggerFrom(ctx).Debug("opening the browser failed", "err", err)
+		fmt.Println(url)
+	}
+
+	return nil
+}
+
+// searchWebURL builds the url of the github search page for term, kind is
+// the type of results, e.g. repositories or users.
+func searchWebURL(term, kind, sort string) string {
+	query := url.Values{}
+	query.Set("q", term)
+	query.Set("type", kind)
+
+	if sort != "" {
+		query.Set("s", sort)
+		query.Set("o", "desc")
+	}
+
+	return "https://github.com/search?" + query.Encode()
+}
+
+// openBrowserAll opens every url, e.g. for the picked results of a search.
+func openBrowserAll(ctx context.Context, urls []string) error {
+	for _, u := range urls {
+		err := openBrowser(ctx, u)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
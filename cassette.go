@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// sanitizedHeaders are left out of cassettes, they carry credentials.
+var sanitizedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// cassette is a recorded request to github and its response.
+type cassette struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+		// Base64 is set for binary bodies, like release assets or log
+		// archives, which are stored base64 encoded.
+		Base64 bool `json:"base64,omitempty"`
+	} `json:"response"`
+}
+
+// cassetteTransport records the requests going through it to cassette files
+// in dir, or serves the responses from them when replaying. A request sent
+// more than once in a run, like the polls of --watch, gets a cassette per
+// send and is replayed in the same order.
+type cassetteTransport struct {
+	dir    string
+	replay bool
+	log    *slog.Logger
+	next   http.RoundTripper
+
+	mu    sync.Mutex
+	sends map[string]int
+}
+
+// setupCassettes installs the cassette transport on the default http client
+// when GCF_RECORD or GCF_REPLAY name a fixtures directory.
+func setupCassettes(log *slog.Logger) {
+	if dir := os.Getenv("GCF_REPLAY"); dir != "" {
+		log.Debug("cassette: replaying", "dir", dir)
+		http.DefaultClient.Transport = &cassetteTransport{dir: dir, replay: true, log: log, sends: make(map[string]int)}
+		return
+	}
+
+	if dir := os.Getenv("GCF_RECORD"); dir != "" {
+		log.Debug("cassette: recording", "dir", dir)
+		http.DefaultClient.Transport = &cassetteTransport{dir: dir, log: log, next: http.DefaultTransport, sends: make(map[string]int)}
+	}
+}
+
+// cassettePath names the cassette of the nth send of a request after its
+// method, url and body.
+func (t *cassetteTransport) cassettePath(req *http.Request, body []byte) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String() + "\n" + string(body)))
+	key := hex.EncodeToString(sum[:8])
+
+	t.mu.Lock()
+	n := t.sends[key]
+	t.sends[key]++
+	t.mu.Unlock()
+
+	return filepath.Join(t.dir, fmt.Sprintf("%s-%s-%d.json", strings.ToLower(req.Method), key, n))
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	path := t.cassettePath(req, body)
+
+	if t.replay {
+		return t.serve(req, path)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(data))
+
+	err = t.record(req, body, res, data, path)
+	if err != nil {
+		// A failed recording does not fail the command.
+		t.log.Warn("recording a cassette failed", "method", req.Method, "url", req.URL.String(), "err", err)
+	}
+
+	return res, nil
+}
+
+func (t *cassetteTransport) record(req *http.Request, body []byte, res *http.Response, data []byte, path string) error {
+	c := cassette{}
+
+	c.Request.Method = req.Method
+	c.Request.URL = redact(req.URL.String())
+	c.Request.Header = sanitizeHeader(req.Header)
+	c.Request.Body = sanitizeBody(string(body))
+
+	c.Response.Status = res.StatusCode
+	c.Response.Header = sanitizeHeader(res.Header)
+
+	if utf8.Valid(data) {
+		c.Response.Body = sanitizeBody(string(data))
+	} else {
+		c.Response.Body = base64.StdEncoding.EncodeToString(data)
+		c.Response.Base64 = true
+	}
+
+	encoded, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(t.dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	t.log.Debug("cassette: recorded", "file", path)
+
+	return os.WriteFile(path, encoded, 0o644)
+}
+
+func (t *cassetteTransport) serve(req *http.Request, path string) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no recorded cassette for %s %s in %s, record it with GCF_RECORD", req.Method, req.URL, t.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := cassette{}
+
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %v", path, err)
+	}
+
+	body := []byte(c.Response.Body)
+	if c.Response.Base64 {
+		body, err = base64.StdEncoding.DecodeString(c.Response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %v", path, err)
+		}
+	}
+
+	t.log.Debug("cassette: replayed", "file", path)
+	timings.cacheHit()
+
+	header := c.Response.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Response.Status, http.StatusText(c.Response.Status)),
+		StatusCode:    c.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// sanitizeHeader leaves out the sanitizedHeaders and masks the secrets in
+// the others, like the signed url of a redirect to a download.
+func sanitizeHeader(header http.Header) http.Header {
+	clean := header.Clone()
+
+	for _, name := range sanitizedHeaders {
+		clean.Del(name)
+	}
+
+	for _, values := range clean {
+		for i, value := range values {
+			values[i] = redact(value)
+		}
+	}
+
+	return clean
+}
+
+// sanitizeBody masks the secrets in recorded bodies, like the tokens of the
+// download urls of private files, or the token in case the api echoes it.
+func sanitizeBody(body string) string {
+	return redact(body)
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// checkResult is a ci result for a commit, either a check run of a github
+// app or a commit status reported through the statuses api.
+type checkResult = github.CheckRun
+
+// findChecks fetches the check runs and the commit statuses of ref and
+// merges them into a single list.
+func findChecks(ctx context.Context, owner, name, ref string) ([]checkResult, error) {
+	client := githubClient()
+
+	checks, err := client.ListCheckRuns(ctx, owner, name, ref)
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+
+	combined, err := client.GetCombinedStatus(ctx, owner, name, ref)
+	if err != nil {
+		return nil, requestError(ctx, err)
+	}
+
+	for _, s := range combined.Statuses {
+		c := checkResult{
+			Name:       s.Context,
+			Status:     "completed",
+			Conclusion: s.State,
+			StartedAt:  s.CreatedAt,
+		}
+
+		if s.State == "pending" {
+			c.Status, c.Conclusion = "in_progress", ""
+		} else {
+			c.CompletedAt = s.UpdatedAt
+		}
+
+		checks = append(checks, c)
+	}
+
+	return checks, nil
+}
+
+// checkOutcome condenses the status and conclusion of a check into passed,
+// failed, skipped or pending.
+func checkOutcome(c checkResult) string {
+	if c.Status != "completed" {
+		return "pending"
+	}
+
+	switch c.Conclusion {
+	case "success":
+		return "passed"
+	case "skipped", "neutral":
+		return "skipped"
+	default:
+		return "failed"
+	}
+}
+
+// summarizeChecks returns a one line summary like "3 passed, 1 failed".
+func summarizeChecks(checks []checkResult) string {
+	if len(checks) == 0 {
+		return "no checks"
+	}
+
+	counts := make(map[string]int)
+	for _, c := range checks {
+		counts[checkOutcome(c)]++
+	}
+
+	parts := make([]string, 0)
+
+	for _, outcome := range []string{"passed", "failed", "pending", "skipped"} {
+		if counts[outcome] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[outcome], outcome))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func executeRepoChecks(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo checks", flag.ExitOnError)
+
+	watch := flagSet.Bool("watch", false, "poll the checks until all of them completed")
+	interval := flagSet.Duration("interval", 10*time.Second, "how often to poll the checks with --watch")
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) < 2 {
+		return usageError("provide a repository and a commit or branch: repo checks <owner/name> <sha|branch>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	ref := args[1]
+
+	loggerFrom(ctx).Debug("repo checks", "repo", owner+"/"+name, "ref", ref)
+
+	for {
+		checks, err := findChecks(ctx, owner, name, ref)
+		if err != nil {
+			return err
+		}
+
+		pending := 0
+		failed := 0
+
+		for _, c := range checks {
+			switch checkOutcome(c) {
+			case "pending":
+				pending++
+			case "failed":
+				failed++
+			}
+		}
+
+		if !*watch || pending == 0 {
+			err := printChecks(checks)
+			if err != nil {
+				return err
+			}
+
+			if *watch && failed > 0 {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "%s waiting for checks: %s\n", time.Now().Format("15:04:05"), summarizeChecks(checks))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(*interval):
+		}
+	}
+}
+
+func printChecks(checks []checkResult) error {
+	if jsonOutput() {
+		return printJSON(checks)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tRESULT\tCONCLUSION\tDURATION")
+
+	for _, c := range checks {
+		conclusion := c.Conclusion
+		if conclusion == "" {
+			conclusion = c.Status
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Name, checkOutcome(c), conclusion, checkDuration(c))
+	}
+
+	err := w.Flush()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s\n", summarizeChecks(checks))
+
+	return nil
+}
+
+// checkDuration returns how long a check ran, or has been running so far.
+func checkDuration(c checkResult) string {
+	if c.StartedAt.IsZero() {
+		return "-"
+	}
+
+	end := c.CompletedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	return end.Sub(c.StartedAt).Round(time.Second).String()
+}
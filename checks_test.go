@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRepoChecksWatch(t *testing.T) {
+	polls := []string{
+		`{"check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}, {"name": "test", "status": "in_progress"}]}`,
+		`{"check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}, {"name": "test", "status": "completed", "conclusion": "failure"}]}`,
+	}
+	polled := 0
+
+	withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/repos/octocat/hello-world/commits/main/check-runs":
+			polled++
+			return respondJSON(req, polls[polled-1]), nil
+		case "/repos/octocat/hello-world/commits/main/status":
+			return respondJSON(req, `{"state": "success", "statuses": [{"context": "lint", "state": "success"}]}`), nil
+		default:
+			t.Errorf("unexpected request %s", req.URL.Path)
+			return respondJSON(req, `{}`), nil
+		}
+	}))
+
+	out, err := captureStdout(func() error {
+		return executeRepoChecks(context.Background(), []string{"--watch", "--interval", "1ms", "octocat/hello-world", "main"})
+	})
+	if err == nil || err.Error() != "1 checks failed" {
+		t.Errorf("err = %v, want 1 checks failed", err)
+	}
+
+	if polled != 2 {
+		t.Errorf("polled %d times, want 2", polled)
+	}
+
+	for _, name := range []string{"build", "test", "lint"} {
+		if !strings.Contains(string(out), name) {
+			t.Errorf("output %q is missing the check %s", out, name)
+		}
+	}
+}
+
+func TestRepoChecksWatchStopsWhenCancelled(t *testing.T) {
+	withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/status") {
+			return respondJSON(req, `{"statuses": []}`), nil
+		}
+
+		return respondJSON(req, `{"check_runs": [{"name": "test", "status": "in_progress"}]}`), nil
+	}))
+
+	// Cancelled while waiting for the next poll of the pending checks.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executeRepoChecks(ctx, []string{"--watch", "--interval", "1h", "octocat/hello-world", "main"})
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("executeRepoChecks = nil, want the error of the cancelled context")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("executeRepoChecks did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// copyToClipboard puts text on the system clipboard using the clipboard tool
+// of the platform.
+func copyToClipboard(ctx context.Context, text string) error {
+	var candidates [][]string
+
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		candidates = [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+		}
+	}
+
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err != nil {
+			continue
+		}
+
+		loggerFrom(ctx).Debug("clipboard", "command", c)
+
+		cmd := exec.Command(c[0], c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+
+		return cmd.Run()
+	}
+
+	return errors.New("no clipboard tool found, install xclip, xsel or wl-clipboard")
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var codespacesUsage = `Specify a codespaces command to execute:
+  - list: List your codespaces
+  - stop: Stop running codespaces
+  - delete: Delete codespaces`
+
+func executeCodespaces(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(codespacesUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("codespaces", "command", command)
+
+	switch command {
+	case "list":
+		return executeCodespacesList(ctx, args[1:])
+	case "stop":
+		return executeCodespacesStop(ctx, args[1:])
+	case "delete":
+		return executeCodespacesDelete(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid codespaces command: '%s'", command))
+	}
+}
+
+type codespace = github.Codespace
+
+func codespaceMachine(c codespace) string {
+	if c.Machine == nil {
+		return "-"
+	}
+
+	return c.Machine.DisplayName
+}
+
+func executeCodespacesList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("codespaces list", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "only list the codespaces of a repository, owner/name")
+
+	parseArgs(flagSet, args)
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("codespaces list", "repo", *repo)
+
+	client := githubClient()
+	pages := client.ListCodespaces(ctx)
+
+	if *repo != "" {
+		owner, name, err := parseRepoName(*repo)
+		if err != nil {
+			return err
+		}
+		pages = client.ListRepositoryCodespaces(ctx, owner, name)
+	}
+
+	codespaces, err := collectPages(ctx, pages, 0, nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(codespaces)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tREPOSITORY\tBRANCH\tMACHINE\tSTATE\tLAST USED")
+
+	for _, c := range codespaces {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s ago\n", c.Name, c.Repository.FullName, c.GitStatus.Ref, codespaceMachine(c), c.State, formatAge(c.LastUsedAt))
+	}
+
+	return w.Flush()
+}
+
+func executeCodespacesStop(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("codespaces stop", flag.ExitOnError)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Codespace")
+
+	if len(args) == 0 {
+		return usageError("provide a codespace: codespaces stop <name>...")
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	return forEachCodespace(ctx, args, "stop", "stopped", func(name string) error {
+		return githubClient().StopCodespace(ctx, name)
+	})
+}
+
+func executeCodespacesDelete(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("codespaces delete", flag.ExitOnError)
+
+	yes := flagSet.Bool("yes", false, "delete without asking for confirmation")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Codespace")
+
+	if len(args) == 0 {
+		return usageError("provide a codespace: codespaces delete <name>...")
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	err = confirm(fmt.Sprintf("Delete %s? Uncommitted changes are lost.", strings.Join(args, ", ")), *yes)
+	if err != nil {
+		return err
+	}
+
+	return forEachCodespace(ctx, args, "delete", "deleted", func(name string) error {
+		return githubClient().DeleteCodespace(ctx, name)
+	})
+}
+
+// forEachCodespace runs action for every named codespace, continuing past
+// failures so a cleanup of many codespaces does not stop at the first one.
+func forEachCodespace(ctx context.Context, names []string, verb, done string, action func(name string) error) error {
+	failed := 0
+
+	for _, name := range names {
+		loggerFrom(ctx).Debug("codespaces "+verb, "codespace", name)
+
+		err := requestError(ctx, action(name))
+		if errors.Is(err, errNotFound) {
+			err = errors.New("codespace not found")
+		}
+		if errors.Is(err, errDryRun) {
+			continue
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			continue
+		}
+
+		fmt.Printf("%s: %s\n", name, done)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d codespaces", verb, failed, len(names))
+	}
+
+	return nil
+}
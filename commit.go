@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+type (
+	commit      = github.Commit
+	changedFile = github.ChangedFile
+)
+
+// shortSHA abbreviates a commit sha like git does.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+
+	return sha
+}
+
+// firstLine returns the subject line of a commit message.
+func firstLine(msg string) string {
+	return strings.SplitN(msg, "\n", 2)[0]
+}
+
+func printChangedFiles(files []changedFile) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, f := range files {
+		fmt.Fprintf(w, "%s\t+%d\t-%d\t%s\n", f.Status, f.Additions, f.Deletions, f.Filename)
+	}
+
+	return w.Flush()
+}
+
+func executeRepoCompare(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo compare", flag.ExitOnError)
+
+	patch := flagSet.Bool("patch", false, "print the diff between base and head")
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) < 2 {
+		return usageError("provide a repository and what to compare: repo compare <owner/name> <base>...<head>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !strings.Contains(args[1], "...") {
+		return usageErrorf("invalid comparison '%s', expected base...head", args[1])
+	}
+
+	loggerFrom(ctx).Debug("repo compare", "repo", owner+"/"+name, "compare", args[1])
+
+	client := githubClient()
+
+	if *patch {
+		return requestError(ctx, client.GetComparisonDiff(ctx, owner, name, args[1], os.Stdout))
+	}
+
+	result, err := client.CompareCommits(ctx, owner, name, args[1])
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	if jsonOutput() {
+		return printJSON(result)
+	}
+
+	fmt.Printf("%s: %d ahead, %d behind\n", result.Status, result.AheadBy, result.BehindBy)
+
+	fmt.Printf("\nCommits (%d):\n", len(result.Commits))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, c := range result.Commits {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", shortSHA(c.SHA), c.Commit.Author.Name, firstLine(c.Commit.Message))
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nFiles (%d):\n", len(result.Files))
+
+	return printChangedFiles(result.Files)
+}
+
+var commitUsage = `Specify a commit command to execute:
+  - view: Show the details of a commit`
+
+func executeCommit(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(commitUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("commit", "command", command)
+
+	if piped(args[1:]) {
+		return forEachIdentifier(args[1:], func(args []string) error {
+			return executeCommit(ctx, append([]string{command}, args...))
+		})
+	}
+
+	switch command {
+	case "view":
+		return executeCommitView(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid commit command: '%s'", command))
+	}
+}
+
+func executeCommitView(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("commit view", flag.ExitOnError)
+
+	patch := flagSet.Bool("patch", false, "print the diff of the commit")
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) < 2 {
+		return usageError("provide a repository and a commit: commit view <owner/name> <sha>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	sha := args[1]
+
+	loggerFrom(ctx).Debug("commit view", "repo", owner+"/"+name, "sha", sha)
+
+	client := githubClient()
+
+	if *patch {
+		return requestError(ctx, client.GetCommitPatch(ctx, owner, name, sha, os.Stdout))
+	}
+
+	c, err := client.GetCommit(ctx, owner, name, sha)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	checks, err := findChecks(ctx, owner, name, c.SHA)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string]interface{}{
+			"commit": c,
+			"checks": checks,
+		})
+	}
+
+	fmt.Printf("commit %s\n", c.SHA)
+	fmt.Printf("Author: %s\n", c.Commit.Author.Name)
+	fmt.Printf("Date:   %s\n", c.Commit.Author.Date.Local().Format(time.RFC1123))
+	fmt.Printf("Checks: %s\n\n", summarizeChecks(checks))
+
+	for _, line := range strings.Split(strings.TrimRight(c.Commit.Message, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+
+	fmt.Printf("\n%d files changed, %d insertions(+), %d deletions(-)\n", len(c.Files), c.Stats.Additions, c.Stats.Deletions)
+
+	return printChangedFiles(c.Files)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// config holds the user settings from config.json in the go-cli-flag
+// directory of the user config dir, e.g. ~/.config/go-cli-flag/config.json.
+type config struct {
+	// GitProtocol is the protocol used for git remotes, https or ssh.
+	GitProtocol string `json:"git_protocol"`
+
+	// History turns the recording of executed commands on or off.
+	History bool `json:"history"`
+
+	// Index turns the local index of fetched repositories and users on or off.
+	Index bool `json:"index"`
+
+	// LogFile is a file the diagnostics are also appended to.
+	LogFile string `json:"log_file"`
+
+	// Audit turns the audit log of changes made on github on or off.
+	Audit bool `json:"audit"`
+
+	// Secrets are masked in the logs, traces and crash reports, on top of
+	// the tokens and keys go-cli-flag knows of.
+	Secrets []string `json:"secrets"`
+}
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "go-cli-flag", "config.json"), nil
+}
+
+// dataDir returns the directory for the data go-cli-flag keeps, like the
+// history. It follows XDG_DATA_HOME and defaults to ~/.local/share, on macOS
+// and windows the user config dir is used.
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "go-cli-flag"), nil
+	}
+
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+
+		return filepath.Join(dir, "go-cli-flag"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "share", "go-cli-flag"), nil
+}
+
+// loadConfig reads the config file, a missing file gives the defaults.
+func loadConfig(ctx context.Context) (config, error) {
+	cfg, err := readConfig()
+	if err != nil {
+		return cfg, err
+	}
+
+	path, _ := configPath()
+	loggerFrom(ctx).Debug("config: loaded", "file", path)
+
+	return cfg, nil
+}
+
+// readConfig is loadConfig without logging, for the logger itself.
+func readConfig() (config, error) {
+	cfg := config{
+		GitProtocol: "https",
+		History:     true,
+		Index:       true,
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	err = json.Unmarshal(data, &cfg)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
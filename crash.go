@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	rtdebug "runtime/debug"
+	"strings"
+	"time"
+)
+
+const issuesURL = "https://github.com/gurleensethi/go-cli-flag/issues/new"
+
+// secretFlags are the names of the flags whose values are left out of crash
+// reports and masked in the diagnostics. Names are matched exactly, flags
+// like --since-field hold no secrets.
+var secretFlags = []string{"token", "api-key", "secret", "password", "header", "alert-url"}
+
+// recoverCrash turns a panic of a command into a crash report instead of a
+// raw go panic dump. It is deferred first thing in main.
+func recoverCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	stack := rtdebug.Stack()
+
+	path, err := writeCrashReport(r, stack)
+	if err != nil {
+		// Without a report the stack is the only trace left.
+		fmt.Fprintf(os.Stderr, "go-cli-flag crashed: %s\n\n%s", redact(fmt.Sprint(r)), stack)
+		os.Exit(70)
+	}
+
+	fmt.Fprintf(os.Stderr, "go-cli-flag crashed: %s\n\n", redact(fmt.Sprint(r)))
+	fmt.Fprintf(os.Stderr, "A crash report was written to %s\n", path)
+	fmt.Fprintf(os.Stderr, "Please file an issue at %s with what you ran and the report attached,\ncheck it for anything private first.\n", issuesURL)
+
+	os.Exit(70)
+}
+
+// writeCrashReport writes the panic, version and the arguments with secrets
+// masked to a file in the temp directory and returns its path.
+func writeCrashReport(r interface{}, stack []byte) (string, error) {
+	file, err := os.CreateTemp("", "go-cli-flag-crash-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "go-cli-flag crash report\n\n")
+	fmt.Fprintf(file, "Time: %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(file, "Version: %s\n", buildVersion())
+	fmt.Fprintf(file, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(file, "Args: %s\n", historyEntry{Args: sanitizeArgs(os.Args[1:])}.command())
+	fmt.Fprintf(file, "Request id: %s\n\n", requestID)
+	fmt.Fprintf(file, "Panic: %s\n\n%s", redact(fmt.Sprint(r)), stack)
+
+	path, err := filepath.Abs(file.Name())
+	if err != nil {
+		return file.Name(), nil
+	}
+
+	return path, nil
+}
+
+// buildVersion returns the module version and vcs revision of the binary.
+func buildVersion() string {
+	info, ok := rtdebug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	version := info.Main.Version
+
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			version += " " + setting.Value
+		}
+		if setting.Key == "vcs.modified" && setting.Value == "true" {
+			version += " (modified)"
+		}
+	}
+
+	return version
+}
+
+// sanitizeArgs replaces the values of secretFlags and other secrets with
+// "***".
+func sanitizeArgs(args []string) []string {
+	sanitized := make([]string, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := redact(args[i])
+
+		if strings.HasPrefix(arg, "-") {
+			if name, _, ok := strings.Cut(arg, "="); ok && secretFlag(name) {
+				arg = name + "=***"
+			} else if secretFlag(arg) && i+1 < len(args) {
+				sanitized[i] = arg
+				i++
+				arg = "***"
+			}
+		}
+
+		sanitized[i] = arg
+	}
+
+	return sanitized
+}
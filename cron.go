@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed five field cron expression: minute, hour, day of
+// month, month and day of week.
+type cronSchedule struct {
+	minutes  [60]bool
+	hours    [24]bool
+	days     [32]bool
+	months   [13]bool
+	weekdays [7]bool
+
+	// Like cron, a day matches either field when both day of month and day
+	// of week are restricted.
+	anyDay     bool
+	anyWeekday bool
+}
+
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonths = []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+var cronWeekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// parseCron parses a cron expression like "*/15 * * * *". Fields take
+// numbers, names for months and weekdays, ranges, lists and steps, and the
+// @hourly style aliases are supported.
+func parseCron(expr string) (*cronSchedule, error) {
+	if alias, ok := cronAliases[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = alias
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s', expected five fields: minute hour day month weekday", expr)
+	}
+
+	s := &cronSchedule{
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}
+
+	err := parseCronField(fields[0], 0, 59, nil, s.minutes[:])
+	if err == nil {
+		err = parseCronField(fields[1], 0, 23, nil, s.hours[:])
+	}
+	if err == nil {
+		err = parseCronField(fields[2], 1, 31, nil, s.days[:])
+	}
+	if err == nil {
+		err = parseCronField(fields[3], 1, 12, cronMonths, s.months[:])
+	}
+	if err == nil {
+		// 7 is sunday too.
+		var weekdays [8]bool
+		err = parseCronField(fields[4], 0, 7, cronWeekdays, weekdays[:])
+		copy(s.weekdays[:], weekdays[:7])
+		s.weekdays[0] = s.weekdays[0] || weekdays[7]
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression '%s': %v", expr, err)
+	}
+
+	return s, nil
+}
+
+// parseCronField marks the values of a comma separated field in set.
+func parseCronField(field string, min, max int, names []string, set []bool) error {
+	value := func(s string) (int, error) {
+		for i, name := range names {
+			if name != "" && strings.EqualFold(s, name) {
+				return i, nil
+			}
+		}
+
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("'%s' is not between %d and %d", s, min, max)
+		}
+
+		return n, nil
+	}
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return fmt.Errorf("invalid step '%s'", stepPart)
+			}
+		}
+
+		start, end := min, max
+
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			start, err = value(from)
+			if err != nil {
+				return err
+			}
+
+			end = start
+			if isRange {
+				end, err = value(to)
+				if err != nil {
+					return err
+				}
+			} else if hasStep {
+				// 5/10 means from 5 on every 10.
+				end = max
+			}
+
+			if end < start {
+				return fmt.Errorf("invalid range '%s'", rangePart)
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			set[i] = true
+		}
+	}
+
+	return nil
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	day := s.days[t.Day()]
+	weekday := s.weekdays[t.Weekday()]
+
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// next returns the first time after t the schedule matches, in the location
+// of t. Non matching months, days and hours are skipped whole. It gives up
+// after five years, for expressions like the 31st of february.
+func (s *cronSchedule) next(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !s.months[t.Month()] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t, true
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"* * * * someday",
+		"1, * * * *",
+		"@never",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := parseCron(expr); err == nil {
+				t.Errorf("parseCron(%q) did not fail", expr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// A wednesday.
+	from := time.Date(2026, 10, 14, 10, 26, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2026, 10, 14, 10, 27, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)},
+		// From 5 on every 10.
+		{expr: "5/10 * * * *", want: time.Date(2026, 10, 14, 10, 35, 0, 0, time.UTC)},
+		{expr: "20-40/10 * * * *", want: time.Date(2026, 10, 14, 10, 30, 0, 0, time.UTC)},
+		{expr: "0,26 * * * *", want: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2026, 10, 14, 11, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{expr: "@yearly", want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// 0 and 7 are both sunday.
+		{expr: "0 9 * * 0", want: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", want: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * sun", want: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 5-7", want: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 12 * * MON-FRI", from: time.Date(2026, 10, 16, 13, 0, 0, 0, time.UTC), want: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)},
+		{expr: "0 0 13 * *", want: time.Date(2026, 11, 13, 0, 0, 0, 0, time.UTC)},
+		// The 13th or a friday, whichever comes first.
+		{expr: "0 0 13 * 5", want: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 15 * 1", want: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{expr: "30 8 1 jan-mar *", want: time.Date(2027, 1, 1, 8, 30, 0, 0, time.UTC)},
+		{expr: "0 0 31 * *", want: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 31 * *", from: time.Date(2026, 10, 31, 1, 0, 0, 0, time.UTC), want: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Never matches, next gives up.
+		{expr: "0 0 30 2 *"},
+		{expr: "0 0 31 4,6,9,11 *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := tt.from
+			if start.IsZero() {
+				start = from
+			}
+
+			got, ok := schedule.next(start)
+			if ok != !tt.want.IsZero() || !got.Equal(tt.want) {
+				t.Errorf("next(%s) = %s, %t, want %s", start, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNextLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	schedule, err := parseCron("30 2 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hours are those of the location, not utc.
+	got, ok := schedule.next(time.Date(2026, 10, 14, 12, 0, 0, 0, berlin))
+	want := time.Date(2026, 10, 15, 2, 30, 0, 0, berlin)
+	if !ok || !got.Equal(want) || got.Location() != berlin {
+		t.Errorf("next = %s, want %s", got, want)
+	}
+}
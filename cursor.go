@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// resumePages is set by the --resume flag of the paginated commands.
+var resumePages bool
+
+// pageCursor is the progress of an interrupted fetch of every page: the url
+// of the next page and the items collected before it.
+type pageCursor struct {
+	Key     string          `json:"key"`
+	Next    string          `json:"next"`
+	Items   json.RawMessage `json:"items"`
+	SavedAt time.Time       `json:"saved_at"`
+}
+
+// cursorKey identifies a fetch by the command line, without the top level
+// flags and --resume, and the url of the first page. Filters applied while
+// paginating are part of the command line so a cursor is only resumed by the
+// same command.
+func cursorKey(first string) string {
+	args := make([]string, 0, flag.NArg())
+
+	for _, arg := range flag.Args() {
+		name := strings.TrimLeft(arg, "-")
+		if arg != name && (name == "resume" || strings.HasPrefix(name, "resume=")) {
+			continue
+		}
+		args = append(args, arg)
+	}
+
+	return strings.Join(args, " ") + "\n" + first
+}
+
+func cursorPath(key string) (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(dir, "cursors", hex.EncodeToString(sum[:16])+".json"), nil
+}
+
+// loadCursor returns the cursor saved for key, if any.
+func loadCursor(ctx context.Context, key string) (pageCursor, bool) {
+	cursor := pageCursor{}
+
+	path, err := cursorPath(key)
+	if err != nil {
+		return cursor, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cursor, false
+	}
+
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.Key != key {
+		loggerFrom(ctx).Debug("cursor: ignoring", "file", path, "err", err)
+		return cursor, false
+	}
+
+	return cursor, true
+}
+
+// saveCursor checkpoints a fetch after a page. Like the history failing to
+// save is only reported in the debug output, the fetch goes on.
+func saveCursor(ctx context.Context, key, next string, items interface{}) {
+	path, err := cursorPath(key)
+	if err != nil {
+		loggerFrom(ctx).Debug("cursor", "err", err)
+		return
+	}
+
+	data, err := json.Marshal(items)
+	if err == nil {
+		data, err = json.Marshal(pageCursor{Key: key, Next: next, Items: data, SavedAt: time.Now()})
+	}
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(path+".tmp", data, 0o600)
+	}
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		loggerFrom(ctx).Debug("cursor", "err", err)
+	}
+}
+
+func removeCursor(ctx context.Context, key string) {
+	path, err := cursorPath(key)
+	if err != nil {
+		return
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		loggerFrom(ctx).Debug("cursor", "err", err)
+	}
+}
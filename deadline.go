@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// errDeadline is what requests past the -deadline fail with.
+var errDeadline = errors.New("deadline exceeded")
+
+// deadlineError is the error of a command running past the -deadline.
+func deadlineError() error {
+	return &githubError{Category: categoryTimeout, Message: fmt.Sprintf("the deadline of %s was exceeded", *deadline), Err: errDeadline}
+}
+
+// deadlineTransport gives every request the time left of the -deadline of
+// the run, a request in flight at the deadline is cancelled and later ones
+// fail right away, however many pages or retries a command is at.
+type deadlineTransport struct {
+	at   time.Time
+	next http.RoundTripper
+}
+
+// deadlineHTTP installs the deadline transport on the default http client,
+// in front of the others so retries share the deadline. The returned context
+// is done at the deadline too, so a command waiting anywhere else, like on
+// a key press, returns and main unwinds through its deferred cleanups.
+func deadlineHTTP(ctx context.Context) (context.Context, context.CancelFunc) {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	at := time.Now().Add(*deadline)
+	http.DefaultClient.Transport = &deadlineTransport{at: at, next: next}
+
+	return context.WithDeadline(ctx, at)
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !time.Now().Before(t.at) {
+		return nil, errDeadline
+	}
+
+	ctx, cancel := context.WithDeadline(req.Context(), t.at)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, errDeadline
+		}
+
+		return nil, err
+	}
+
+	// The body is read after RoundTrip returns, it is cancelled with it.
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+
+	return res, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
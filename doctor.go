@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// Results of doctor checks.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+type doctorResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context) (string, string)
+}
+
+var doctorChecks = []doctorCheck{
+	{"go runtime", checkRuntime},
+	{"config", checkConfig},
+	{"credentials", checkCredentials},
+	{"dns", checkDNS},
+	{"tls", checkTLS},
+	{"proxy", checkProxy},
+	{"data directory", checkDataDir},
+	{"rate limit", checkRateLimit},
+}
+
+// executeDoctor runs every check and prints its result, it fails when any
+// check fails so scripts can use it too.
+func executeDoctor(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("doctor", flag.ExitOnError)
+
+	parseArgs(flagSet, args)
+
+	// The checks report on requests themselves.
+	*quiet = true
+
+	results := make([]doctorResult, 0, len(doctorChecks))
+	failed := 0
+
+	for _, check := range doctorChecks {
+		status, detail := check.run(ctx)
+		if status == checkFail {
+			failed++
+		}
+
+		results = append(results, doctorResult{Name: check.name, Status: status, Detail: detail})
+	}
+
+	if jsonOutput() {
+		err := printJSON(results)
+		if err != nil {
+			return err
+		}
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", checkLabel(r.Status), r.Name, r.Detail)
+		}
+
+		w.Flush()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+
+	return nil
+}
+
+func checkLabel(status string) string {
+	label, color := "FAIL", ansiRed
+
+	switch status {
+	case checkPass:
+		label, color = "PASS", ansiGreen
+	case checkWarn:
+		label, color = "WARN", ansiYellow
+	}
+
+	if !colorOutput() {
+		return label
+	}
+
+	return color + label + ansiReset
+}
+
+func checkRuntime(ctx context.Context) (string, string) {
+	return checkPass, fmt.Sprintf("%s %s/%s, go-cli-flag %s", runtime.Version(), runtime.GOOS, runtime.GOARCH, buildVersion())
+}
+
+func checkConfig(ctx context.Context) (string, string) {
+	path, err := configPath()
+	if err != nil {
+		return checkWarn, "no user config directory: " + err.Error()
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return checkPass, "no config file at " + path + ", using the defaults"
+	}
+
+	_, err = loadConfig(ctx)
+	if err != nil {
+		return checkFail, err.Error()
+	}
+
+	return checkPass, path
+}
+
+func checkCredentials(ctx context.Context) (string, string) {
+	if githubToken() == "" {
+		return checkWarn, "no GITHUB_TOKEN or GH_TOKEN, requests are limited to 60 an hour and changes are not possible"
+	}
+
+	client := githubClient()
+
+	req, err := client.NewRequest(ctx, http.MethodGet, "/user", nil, nil)
+	if err != nil {
+		return checkFail, err.Error()
+	}
+
+	// The scopes are in the headers, GetAuthenticatedUser drops them.
+	user := github.UserProfile{}
+
+	res, err := client.Do(req, &user)
+
+	var apiErr *github.Error
+	if errors.As(err, &apiErr) && apiErr.Response.StatusCode == http.StatusUnauthorized {
+		return checkFail, "github rejects the token, it is invalid or expired"
+	}
+	if err != nil {
+		return checkFail, requestError(ctx, err).Error()
+	}
+
+	// Classic tokens list their scopes, fine grained tokens do not.
+	scopes, ok := res.Header["X-Oauth-Scopes"]
+	if !ok {
+		return checkPass, fmt.Sprintf("signed in as %s with a fine grained token", user.Login)
+	}
+
+	if len(scopes) == 0 || scopes[0] == "" {
+		return checkWarn, fmt.Sprintf("signed in as %s with a token without scopes, only public data is accessible", user.Login)
+	}
+
+	return checkPass, fmt.Sprintf("signed in as %s, scopes: %s", user.Login, scopes[0])
+}
+
+func apiHost() (*url.URL, error) {
+	return url.Parse(githubAPI)
+}
+
+func checkDNS(ctx context.Context) (string, string) {
+	u, err := apiHost()
+	if err != nil {
+		return checkFail, err.Error()
+	}
+
+	addrs, err := net.LookupHost(u.Hostname())
+	if err != nil {
+		return checkFail, fmt.Sprintf("failed to resolve %s: %v", u.Hostname(), err)
+	}
+
+	return checkPass, fmt.Sprintf("%s resolves to %s", u.Hostname(), addrs[0])
+}
+
+func checkTLS(ctx context.Context) (string, string) {
+	u, err := apiHost()
+	if err != nil {
+		return checkFail, err.Error()
+	}
+
+	if u.Scheme != "https" {
+		return checkWarn, githubAPI + " is not https"
+	}
+
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", addr, &tls.Config{ServerName: u.Hostname()})
+	if err != nil {
+		return checkFail, fmt.Sprintf("failed to connect to %s: %v", addr, err)
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	cert := state.PeerCertificates[0]
+
+	return checkPass, fmt.Sprintf("%s, certificate valid until %s", tls.VersionName(state.Version), cert.NotAfter.Format("2006-01-02"))
+}
+
+func checkProxy(ctx context.Context) (string, string) {
+	req, err := http.NewRequest(http.MethodGet, githubAPI, nil)
+	if err != nil {
+		return checkFail, err.Error()
+	}
+
+	proxy, err := http.ProxyFromEnvironment(req)
+	if err != nil {
+		return checkFail, "invalid proxy setting: " + err.Error()
+	}
+
+	if proxy == nil {
+		return checkPass, "no proxy"
+	}
+
+	// Proxy urls may carry credentials.
+	return checkPass, "through " + proxy.Scheme + "://" + proxy.Host
+}
+
+func checkDataDir(ctx context.Context) (string, string) {
+	dir, err := dataDir()
+	if err != nil {
+		return checkFail, err.Error()
+	}
+
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		// It is created by the first command needing it.
+		dir = filepath.Dir(dir)
+	}
+
+	file, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return checkFail, fmt.Sprintf("%s is not writable: %v", dir, err)
+	}
+
+	file.Close()
+	os.Remove(file.Name())
+
+	return checkPass, dir + " is writable"
+}
+
+func checkRateLimit(ctx context.Context) (string, string) {
+	limits, err := githubClient().GetRateLimits(ctx)
+	if err != nil {
+		return checkFail, requestError(ctx, err).Error()
+	}
+
+	core, ok := limits["core"]
+	if !ok {
+		return checkWarn, "github sent no core rate limit"
+	}
+
+	search := limits["search"]
+
+	detail := fmt.Sprintf("%d of %d requests left, %d of %d searches, resets at %s", core.Remaining, core.Limit, search.Remaining, search.Limit, time.Unix(core.Reset, 0).Local().Format("15:04"))
+
+	if core.Remaining == 0 {
+		return checkFail, detail
+	}
+
+	if core.Remaining < core.Limit/10 {
+		return checkWarn, detail
+	}
+
+	return checkPass, detail
+}
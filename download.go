@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// downloadFile downloads path of the github api to dest showing a progress
+// bar, like a release asset. The data is first written to dest.part, when
+// that file already exists from an earlier, interrupted download the
+// transfer is resumed using a range request. Size is the expected size of
+// the file, zero when unknown.
+func downloadFile(ctx context.Context, path, dest string, size int64) error {
+	if info, err := os.Stat(dest); err == nil && size > 0 && info.Size() == size {
+		loggerFrom(ctx).Debug("download: already downloaded", "file", dest)
+		return nil
+	}
+
+	partial := dest + ".part"
+
+	var offset int64
+	if info, err := os.Stat(partial); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := newGithubRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		// The path is from the answer of github.
+		return invalidResponse(ctx, err)
+	}
+
+	req.Header.Set("Accept", "application/octet-stream")
+
+	if offset > 0 {
+		loggerFrom(ctx).Debug("download: resuming", "file", dest, "offset", offset)
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := githubClient().Open(req)
+
+	var apiErr *github.Error
+	if offset > 0 && errors.As(err, &apiErr) && apiErr.Response.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// The partial file is not shorter than the file, it cannot be told
+		// whether it is the file, so it is downloaded again.
+		loggerFrom(ctx).Debug("download: partial file not resumable", "file", dest, "offset", offset)
+		os.Remove(partial)
+		return downloadFile(ctx, path, dest, size)
+	}
+	if err != nil {
+		return requestError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+
+	if res.StatusCode == http.StatusPartialContent {
+		flags |= os.O_APPEND
+	} else {
+		// The server ignored the range, start from scratch.
+		offset = 0
+		flags |= os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(partial, flags, 0o644)
+	if err != nil {
+		return err
+	}
+
+	total := size
+	if total <= 0 && res.ContentLength > 0 {
+		total = offset + res.ContentLength
+	}
+
+	progress := newProgressBar(filepath.Base(dest), total, offset)
+
+	_, err = io.Copy(io.MultiWriter(file, progress), res.Body)
+	progress.Finish()
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		loggerFrom(ctx).Debug("download interrupted", "file", dest, "err", err)
+		return fmt.Errorf("download of %s interrupted, run the command again to resume", filepath.Base(dest))
+	}
+
+	return os.Rename(partial, dest)
+}
+
+// verifySHA256 checks that the sha256 checksum of the file matches the hex
+// encoded sum.
+func verifySHA256(path, sum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(hash.Sum(nil)) != sum {
+		return errors.New("checksum mismatch")
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// errDryRun stops a command at its request with -dry-run, it exits
+// successfully.
+var errDryRun = errors.New("dry run, the request was not sent")
+
+// dryRunTransport prints requests instead of sending them. Commands sending a
+// request per argument, like starring many repositories, go on to the next
+// one and print it too.
+type dryRunTransport struct {
+	mu      sync.Mutex
+	printed int
+}
+
+// dryRunHTTP replaces the transports of the default http client with the
+// dry run transport, so no cassette, log or audit entry sees the request.
+func dryRunHTTP() {
+	http.DefaultClient.Transport = &dryRunTransport{}
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.printed > 0 {
+		fmt.Println()
+	}
+	t.printed++
+
+	fmt.Printf("%s %s\n", req.Method, redact(req.URL.String()))
+
+	keys := make([]string, 0, len(req.Header))
+	for key := range req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range req.Header[key] {
+			fmt.Println(redact(key + ": " + value))
+		}
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Println()
+		printAPIBody([]byte(redact(string(data))))
+	}
+
+	return nil, errDryRun
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// editorScissors separates the text from the hint in the file opened in the
+// editor, everything from it on is removed like git does for commit messages.
+// Comment lines are not used as they would remove markdown headings.
+const editorScissors = "------------------------ >8 ------------------------"
+
+// editText opens the editor of the user on a temporary file and returns what
+// was written to it. The editor is taken from $VISUAL or $EDITOR.
+func editText(ctx context.Context, hint string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	file, err := os.CreateTemp("", "go-cli-flag-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = fmt.Fprintf(file, "\n%s\n%s\n", editorScissors, hint)
+	file.Close()
+	if err != nil {
+		return "", err
+	}
+
+	loggerFrom(ctx).Debug("editor", "editor", editor, "file", file.Name())
+
+	// The editor may come with arguments, e.g. "code --wait".
+	parts := strings.Fields(editor)
+
+	cmd := exec.Command(parts[0], append(parts[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("editor '%s' failed: %v", editor, err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+
+	text, _, _ := strings.Cut(string(data), editorScissors)
+
+	return strings.TrimSpace(text), nil
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// Categories of failures, each exits with its own code so scripts can tell
+// them apart.
+const (
+	categoryUsage       = "usage"
+	categoryAuth        = "unauthorized"
+	categoryRateLimited = "rate_limited"
+	categoryNetwork     = "network"
+	categoryNotFound    = "not_found"
+	categoryAPI         = "api_error"
+	categoryTimeout     = "timeout"
+)
+
+// exitCodes are the exit codes of the categories, any other failure exits
+// with 1 and a crash with 70.
+var exitCodes = map[string]int{
+	categoryUsage:       2,
+	categoryAuth:        3,
+	categoryRateLimited: 4,
+	categoryNetwork:     5,
+	categoryNotFound:    6,
+	categoryAPI:         7,
+	categoryTimeout:     8,
+}
+
+// usageError is a command run with missing or invalid arguments.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+func usageErrorf(format string, a ...interface{}) error {
+	return usageError(fmt.Sprintf(format, a...))
+}
+
+// githubError is a failed request to github.
+type githubError struct {
+	Category string
+
+	// StatusCode is the status github answered with, 0 when it could not
+	// be reached.
+	StatusCode int
+
+	Message string
+
+	// ResetAt is when the rate limit resets, for rate limited requests.
+	ResetAt time.Time
+
+	// DocumentationURL is the page of the github docs github linked with
+	// the error.
+	DocumentationURL string
+
+	// Hint is what the user can do about the error.
+	Hint string
+
+	Err error
+}
+
+func (e *githubError) Error() string {
+	return e.Message
+}
+
+func (e *githubError) Unwrap() error {
+	return e.Err
+}
+
+// Is keeps errors.Is(err, errNotFound) and errors.Is(err, errForbidden)
+// working for the callers handling those.
+func (e *githubError) Is(target error) bool {
+	switch target {
+	case errNotFound:
+		return e.Category == categoryNotFound
+	case errForbidden:
+		return e.StatusCode == http.StatusForbidden
+	default:
+		return false
+	}
+}
+
+// networkError is a request that did not get an answer from github.
+func networkError(ctx context.Context, err error) error {
+	if errors.Is(err, errDryRun) {
+		return errDryRun
+	}
+
+	if errors.Is(err, errOffline) {
+		return errOffline
+	}
+
+	if errors.Is(err, errDeadline) {
+		return deadlineError()
+	}
+
+	loggerFrom(ctx).Debug("request failed", "err", err)
+
+	cause := err
+	if unwrapped := errors.Unwrap(err); unwrapped != nil {
+		// Leave out the method and url of *url.Error.
+		cause = unwrapped
+	}
+
+	return &githubError{Category: categoryNetwork, Message: "failed to connect to github: " + cause.Error(), Err: err}
+}
+
+// invalidResponse is an answer of github that could not be decoded.
+func invalidResponse(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return deadlineError()
+	}
+
+	loggerFrom(ctx).Debug("invalid response", "err", err)
+
+	return &githubError{Category: categoryAPI, Message: "invalid response from github", Err: err}
+}
+
+// requestError returns the error of a request sent with the github client:
+// an error github answered with, a failed graphql query, a response that did
+// not decode or no answer at all. It is nil for a nil err.
+func requestError(ctx context.Context, err error) error {
+	var apiErr *github.Error
+	var queryErr github.GraphQLErrors
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &apiErr):
+		return apiError(ctx, apiErr)
+	case errors.As(err, &queryErr):
+		loggerFrom(ctx).Debug("graphql", "errors", []github.GraphQLError(queryErr))
+		if queryErr.NotFound() {
+			return &githubError{Category: categoryNotFound, Message: errNotFound.Error() + ": " + queryErr.Error(), Err: err}
+		}
+		return &githubError{Category: categoryAPI, Message: "github graphql error: " + queryErr.Error(), Err: err}
+	case errors.Is(err, github.ErrInvalidResponse):
+		return invalidResponse(ctx, err)
+	default:
+		return networkError(ctx, err)
+	}
+}
+
+// errorDetail returns the message and errors github answered with, e.g.
+// "validation failed: q cannot be empty", empty when github sent neither.
+func errorDetail(apiErr *github.Error) string {
+	details := make([]string, 0, len(apiErr.Errors))
+
+	for _, e := range apiErr.Errors {
+		switch {
+		case e.Message != "":
+			details = append(details, e.Message)
+		case e.Field != "" && e.Code != "":
+			details = append(details, e.Field+" is "+strings.ReplaceAll(e.Code, "_", " "))
+		case e.Code != "":
+			details = append(details, strings.ReplaceAll(e.Code, "_", " "))
+		}
+	}
+
+	message := apiErr.Message
+	if titleCase(message) {
+		// Like "Validation Failed", other messages are kept as is.
+		message = strings.ToLower(message)
+	}
+
+	if len(details) == 0 {
+		return message
+	}
+	if message == "" {
+		return strings.Join(details, ", ")
+	}
+
+	return message + ": " + strings.Join(details, ", ")
+}
+
+func titleCase(s string) bool {
+	words := strings.Fields(s)
+
+	for _, w := range words {
+		if w[0] < 'A' || w[0] > 'Z' {
+			return false
+		}
+	}
+
+	return len(words) > 0
+}
+
+// responseError returns the error of a response with a status other than
+// 2xx, with the details github gave in its body.
+func responseError(ctx context.Context, res *http.Response) error {
+	var apiErr *github.Error
+	if !errors.As(github.CheckResponse(res), &apiErr) {
+		return nil
+	}
+
+	return apiError(ctx, apiErr)
+}
+
+// apiError returns the error of the cli for an error github answered with.
+func apiError(ctx context.Context, apiErr *github.Error) error {
+	res := apiErr.Response
+	detail := errorDetail(apiErr)
+
+	e := &githubError{StatusCode: res.StatusCode, DocumentationURL: apiErr.DocumentationURL, Err: apiErr}
+
+	switch {
+	case rateLimited(res) && unauthenticated(res):
+		e.Category = categoryRateLimited
+		e.ResetAt = rateLimitReset(res.Header)
+		limit, remaining := res.Header.Get("X-RateLimit-Limit"), res.Header.Get("X-RateLimit-Remaining")
+		if limit == "" {
+			limit = "60"
+		}
+		if remaining == "" {
+			remaining = "0"
+		}
+		e.Message = fmt.Sprintf("github allows %s requests an hour without a token and %s are left", limit, remaining)
+		if !e.ResetAt.IsZero() {
+			e.Message += fmt.Sprintf(", the limit resets at %s (in %s)", e.ResetAt.Local().Format("15:04 MST"), formatAge(time.Now().Add(-time.Until(e.ResetAt))))
+		}
+		// There is no auth command, the token comes from the environment.
+		e.Hint = "Set GITHUB_TOKEN to a personal access token for 5000 requests an hour"
+		e.DocumentationURL = ""
+	case rateLimited(res):
+		e.Category = categoryRateLimited
+		e.ResetAt = rateLimitReset(res.Header)
+		e.Message = "github rate limit exceeded"
+		if !e.ResetAt.IsZero() {
+			e.Message += ", it resets at " + e.ResetAt.Local().Format("15:04")
+		}
+	case res.StatusCode == http.StatusUnauthorized:
+		e.Category = categoryAuth
+		e.Message = "github rejected the token, it is invalid or expired"
+	case res.StatusCode == http.StatusForbidden:
+		e.Category = categoryAuth
+		e.Message = errForbidden.Error()
+		if detail != "" {
+			e.Message += ": " + detail
+		}
+	case res.StatusCode == http.StatusNotFound:
+		e.Category = categoryNotFound
+		e.Message = errNotFound.Error()
+	default:
+		e.Category = categoryAPI
+		e.Message = "github answered " + res.Status
+		if detail != "" {
+			e.Message = detail
+		}
+	}
+
+	loggerFrom(ctx).Debug("github error", "status", res.StatusCode, "message", apiErr.Message, "documentation_url", apiErr.DocumentationURL)
+
+	return e
+}
+
+// rateLimited reports whether github refused a request for the rate limit,
+// which it answers with a 403 or 429 and no requests remaining, or a
+// Retry-After for the secondary limits.
+func rateLimited(res *http.Response) bool {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+
+	return res.Header.Get("X-RateLimit-Remaining") == "0" || res.Header.Get("Retry-After") != "" || res.StatusCode == http.StatusTooManyRequests
+}
+
+// unauthenticated reports whether the request of res was sent without a
+// token, which github limits to 60 requests an hour.
+func unauthenticated(res *http.Response) bool {
+	if res.Request != nil {
+		return res.Request.Header.Get("Authorization") == ""
+	}
+
+	return githubToken() == ""
+}
+
+func rateLimitReset(header http.Header) time.Time {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+
+	if unix, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(unix, 0)
+	}
+
+	return time.Time{}
+}
+
+// errorCategory returns the category of err, empty for other failures.
+func errorCategory(err error) string {
+	var usage usageError
+	if errors.As(err, &usage) {
+		return categoryUsage
+	}
+
+	var failure *githubError
+	if errors.As(err, &failure) {
+		return failure.Category
+	}
+
+	if errors.Is(err, errOffline) {
+		return categoryNetwork
+	}
+
+	return ""
+}
+
+// exitCode returns the exit code for a command failing with err.
+func exitCode(err error) int {
+	var status exitStatus
+	if errors.As(err, &status) {
+		return int(status)
+	}
+
+	if code, ok := exitCodes[errorCategory(err)]; ok {
+		return code
+	}
+
+	return 1
+}
+
+// jsonError is how errors are printed with -format json.
+type jsonError struct {
+	Code             string     `json:"code"`
+	Message          string     `json:"message"`
+	Status           int        `json:"status,omitempty"`
+	ResetAt          *time.Time `json:"reset_at,omitempty"`
+	DocumentationURL string     `json:"documentation_url,omitempty"`
+	Hint             string     `json:"hint,omitempty"`
+}
+
+// printError prints the error a command failed with on stderr, stdout only
+// carries results. With -format json it is a json object, so scripts can
+// branch on its code instead of parsing the message.
+func printError(err error) {
+	// The child process of history run printed its error itself.
+	var status exitStatus
+	if errors.As(err, &status) {
+		return
+	}
+
+	var failure *githubError
+	errors.As(err, &failure)
+
+	if !jsonOutput() {
+		fmt.Fprintln(os.Stderr, err)
+		if failure != nil && failure.Hint != "" {
+			fmt.Fprintln(os.Stderr, failure.Hint)
+		}
+		if failure != nil && failure.DocumentationURL != "" {
+			fmt.Fprintln(os.Stderr, "See "+failure.DocumentationURL)
+		}
+		return
+	}
+
+	e := jsonError{Code: errorCategory(err), Message: err.Error()}
+	if e.Code == "" {
+		e.Code = "error"
+	}
+
+	if failure != nil {
+		e.Status = failure.StatusCode
+		e.DocumentationURL = failure.DocumentationURL
+		e.Hint = failure.Hint
+		if !failure.ResetAt.IsZero() {
+			e.ResetAt = &failure.ResetAt
+		}
+	}
+
+	json.NewEncoder(os.Stderr).Encode(map[string]jsonError{"error": e})
+}
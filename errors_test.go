@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	_, _, invalidRepo := parseRepoName("golang")
+	_, invalidLogin := parseLogin("octo cat")
+	_, invalidLevel := parseLogLevel("loud")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "invalid owner/name", err: invalidRepo, want: 2},
+		{name: "invalid login", err: invalidLogin, want: 2},
+		{name: "invalid log level", err: invalidLevel, want: 2},
+		{name: "wrapped usage error", err: fmt.Errorf("clone: %w", usageError("provide a repository")), want: 2},
+		{name: "auth", err: &githubError{Category: categoryAuth}, want: 3},
+		{name: "rate limited", err: &githubError{Category: categoryRateLimited}, want: 4},
+		{name: "network", err: &githubError{Category: categoryNetwork}, want: 5},
+		{name: "offline", err: errOffline, want: 5},
+		{name: "not found", err: &githubError{Category: categoryNotFound}, want: 6},
+		{name: "api", err: &githubError{Category: categoryAPI}, want: 7},
+		{name: "deadline", err: deadlineError(), want: 8},
+		{name: "history run", err: exitStatus(42), want: 42},
+		{name: "other", err: errors.New("failed to open the local index"), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Fatal("no error to test")
+			}
+
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
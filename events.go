@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// eventTypes maps the short names accepted by --type to github event types.
+var eventTypes = map[string]string{
+	"push":    "PushEvent",
+	"star":    "WatchEvent",
+	"release": "ReleaseEvent",
+	"fork":    "ForkEvent",
+	"issue":   "IssuesEvent",
+	"pr":      "PullRequestEvent",
+	"comment": "IssueCommentEvent",
+	"create":  "CreateEvent",
+	"delete":  "DeleteEvent",
+}
+
+type event = github.Event
+
+// describeEvent renders what happened in the event as a short sentence.
+func describeEvent(e event) string {
+	var p struct {
+		Action  string `json:"action"`
+		Ref     string `json:"ref"`
+		RefType string `json:"ref_type"`
+		Size    int    `json:"size"`
+		Number  int    `json:"number"`
+		Release struct {
+			TagName string `json:"tag_name"`
+		} `json:"release"`
+		Issue struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+		} `json:"issue"`
+		PullRequest struct {
+			Title string `json:"title"`
+		} `json:"pull_request"`
+		Forkee struct {
+			FullName string `json:"full_name"`
+		} `json:"forkee"`
+	}
+
+	json.Unmarshal(e.Payload, &p)
+
+	switch e.Type {
+	case "PushEvent":
+		return fmt.Sprintf("pushed %d commits to %s", p.Size, strings.TrimPrefix(p.Ref, "refs/heads/"))
+	case "WatchEvent":
+		return "starred the repository"
+	case "ReleaseEvent":
+		return fmt.Sprintf("%s release %s", p.Action, p.Release.TagName)
+	case "ForkEvent":
+		return "forked to " + p.Forkee.FullName
+	case "IssuesEvent":
+		return fmt.Sprintf("%s issue #%d %s", p.Action, p.Issue.Number, truncate(p.Issue.Title, 50))
+	case "IssueCommentEvent":
+		return fmt.Sprintf("commented on #%d %s", p.Issue.Number, truncate(p.Issue.Title, 50))
+	case "PullRequestEvent":
+		return fmt.Sprintf("%s pull request #%d %s", p.Action, p.Number, truncate(p.PullRequest.Title, 50))
+	case "CreateEvent":
+		return strings.TrimSpace(fmt.Sprintf("created %s %s", p.RefType, p.Ref))
+	case "DeleteEvent":
+		return fmt.Sprintf("deleted %s %s", p.RefType, p.Ref)
+	default:
+		return strings.TrimSuffix(e.Type, "Event")
+	}
+}
+
+func executeUserEvents(ctx context.Context, args []string) error {
+	return executeEvents(ctx, "user events", args, func(arg string) (func() *github.Pages[event], error) {
+		login, err := parseLogin(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() *github.Pages[event] {
+			return githubClient().ListUserEvents(ctx, login)
+		}, nil
+	})
+}
+
+func executeRepoEvents(ctx context.Context, args []string) error {
+	return executeEvents(ctx, "repo events", args, func(arg string) (func() *github.Pages[event], error) {
+		owner, name, err := parseRepoName(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() *github.Pages[event] {
+			return githubClient().ListRepositoryEvents(ctx, owner, name)
+		}, nil
+	})
+}
+
+// executeEvents lists the events of the pages built by listEvents from the
+// first argument, listEvents starts over on every poll with --follow.
+func executeEvents(ctx context.Context, command string, args []string, listEvents func(string) (func() *github.Pages[event], error)) error {
+	flagSet := flag.NewFlagSet(command, flag.ExitOnError)
+
+	types := stringList{}
+	flagSet.Var(&types, "type", "only show events of a type: push, star, release, fork, issue, pr, comment, create or delete")
+	follow := flagSet.Bool("follow", false, "keep polling for new events")
+	interval := flagSet.Duration("interval", time.Minute, "how often to poll with --follow, github asks for at least a minute")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) == 0 {
+		return usageErrorf("provide what to show the events of: %s <target>", command)
+	}
+
+	list, err := listEvents(args[0])
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool)
+
+	for _, t := range types {
+		eventType, ok := eventTypes[t]
+		if !ok {
+			return usageErrorf("invalid event type '%s'", t)
+		}
+		wanted[eventType] = true
+	}
+
+	loggerFrom(ctx).Debug(command, "target", args[0], "types", types)
+
+	keep := func(e event) bool {
+		return len(wanted) == 0 || wanted[e.Type]
+	}
+
+	seen := make(map[string]bool)
+
+	for {
+		events, err := collectPages(ctx, list(), page.max(), keep)
+		if err != nil {
+			return err
+		}
+
+		// Events come newest first, the timeline reads top to bottom.
+		for i := len(events) - 1; i >= 0; i-- {
+			e := events[i]
+			if seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+
+			if jsonOutput() {
+				err := json.NewEncoder(os.Stdout).Encode(e)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			fmt.Printf("%s  %-20s %-30s %s\n", e.CreatedAt.Local().Format("2006-01-02 15:04"), e.Actor.Login, e.Repo.Name, describeEvent(e))
+		}
+
+		if !*follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(*interval):
+		}
+	}
+}
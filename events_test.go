@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRepoEventsFollow(t *testing.T) {
+	forkEvent := func(id int) string {
+		return fmt.Sprintf(`{"id": "%d", "type": "ForkEvent", "actor": {"login": "user%d"}, "repo": {"name": "octocat/hello-world"}, "payload": {"forkee": {"full_name": "user%d/hello-world"}}, "created_at": "2024-01-0%dT10:00:00Z"}`, id, id, id, id)
+	}
+
+	// Every poll answers with the newest events first, including those
+	// of the polls before.
+	polls := []string{
+		"[" + forkEvent(2) + "," + forkEvent(1) + "]",
+		"[" + forkEvent(3) + "," + forkEvent(2) + "," + forkEvent(1) + "]",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	polled := 0
+
+	withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/repos/octocat/hello-world/events" {
+			t.Errorf("path = %s", req.URL.Path)
+		}
+
+		polled++
+		if polled > len(polls) {
+			cancel()
+			return nil, ctx.Err()
+		}
+
+		return respondJSON(req, polls[polled-1]), nil
+	}))
+
+	out, err := captureStdout(func() error {
+		return executeRepoEvents(ctx, []string{"--follow", "--interval", "1ms", "octocat/hello-world"})
+	})
+	if err == nil {
+		t.Error("executeRepoEvents = nil, want the error of the cancelled context")
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("printed %d events, want 3:\n%s", len(lines), out)
+	}
+
+	for i, line := range lines {
+		if want := fmt.Sprintf("forked to user%d/hello-world", i+1); !strings.HasSuffix(line, want) {
+			t.Errorf("event %d = %q, want it to end with %q", i+1, line, want)
+		}
+	}
+}
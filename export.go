@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// exportRow is a repository as written by export. The columns are a stable
+// schema for spreadsheets and pipelines: new columns are only appended and
+// existing ones are never renamed or removed.
+type exportRow struct {
+	FullName    string `parquet:"full_name"`
+	Owner       string `parquet:"owner"`
+	Name        string `parquet:"name"`
+	Description string `parquet:"description"`
+	Language    string `parquet:"language"`
+	Topics      string `parquet:"topics"`
+	Stars       int64  `parquet:"stars"`
+	Forks       int64  `parquet:"forks"`
+	Fork        bool   `parquet:"fork"`
+	Archived    bool   `parquet:"archived"`
+	HTMLURL     string `parquet:"html_url"`
+	PushedAt    string `parquet:"pushed_at"`
+}
+
+// exportColumns are the csv headers, in the order of the exportRow fields.
+var exportColumns = []string{"full_name", "owner", "name", "description", "language", "topics", "stars", "forks", "fork", "archived", "html_url", "pushed_at"}
+
+func newExportRow(r repository) exportRow {
+	owner, name, _ := strings.Cut(r.FullName, "/")
+
+	return exportRow{
+		FullName:    r.FullName,
+		Owner:       owner,
+		Name:        name,
+		Description: r.Description,
+		Language:    r.Language,
+		Topics:      strings.Join(r.Topics, ";"),
+		Stars:       int64(r.StargazersCount),
+		Forks:       int64(r.ForksCount),
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		HTMLURL:     r.HTMLURL,
+		PushedAt:    indexTime(r.PushedAt),
+	}
+}
+
+func (e exportRow) record() []string {
+	return []string{
+		e.FullName,
+		e.Owner,
+		e.Name,
+		e.Description,
+		e.Language,
+		e.Topics,
+		strconv.FormatInt(e.Stars, 10),
+		strconv.FormatInt(e.Forks, 10),
+		strconv.FormatBool(e.Fork),
+		strconv.FormatBool(e.Archived),
+		e.HTMLURL,
+		e.PushedAt,
+	}
+}
+
+func writeCSV(w io.Writer, rows []exportRow) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write(exportColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		err := writer.Write(row.record())
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func executeExport(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("export", flag.ExitOnError)
+
+	exportFormat := flagSet.String("format", "csv", "file format: csv or parquet")
+	output := flagSet.String("output", "-", "file to write to, - writes csv to stdout")
+	local := flagSet.Bool("local", false, "export the local index instead of searching github, the search term is optional")
+	sort := flagSet.String("sort", "", "sort results by stars, forks or updated, defaults to best match")
+
+	args = parseArgs(flagSet, args)
+
+	term := strings.Join(args, " ")
+
+	loggerFrom(ctx).Debug("export", "term", term, "format", *exportFormat, "output", *output, "local", *local)
+
+	if *exportFormat != "csv" && *exportFormat != "parquet" {
+		return usageErrorf("invalid format '%s', expected csv or parquet", *exportFormat)
+	}
+
+	if *exportFormat == "parquet" && *output == "-" {
+		return usageError("parquet is a binary format, provide a file: export --format parquet --output <file>")
+	}
+
+	if term == "" && !*local {
+		return usageError("provide a search term, or export the local index: export <search_term> | export --local")
+	}
+
+	var repos []repository
+	var err error
+
+	if *local {
+		repos, err = offlineRepositories(ctx, term, *sort, 0)
+	} else {
+		repos, err = searchRepositories(ctx, term, *sort)
+	}
+	if err != nil {
+		return err
+	}
+
+	rows := make([]exportRow, len(repos))
+	for i, r := range repos {
+		rows[i] = newExportRow(r)
+	}
+
+	if *output == "-" {
+		return writeCSV(os.Stdout, rows)
+	}
+
+	// Write next to the output first so a failed export does not leave a
+	// truncated file behind.
+	tmp := *output + ".tmp"
+
+	if *exportFormat == "parquet" {
+		err = parquet.WriteFile(tmp, rows)
+	} else {
+		var file *os.File
+		file, err = os.Create(tmp)
+		if err == nil {
+			err = writeCSV(file, rows)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if err == nil {
+		err = os.Rename(tmp, *output)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %v", *output, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d repositories to %s\n", len(rows), *output)
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var gistUsage = `Specify a gist command to execute:
+  - create: Create a gist from files or stdin`
+
+func executeGist(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(gistUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("gist", "command", command)
+
+	switch command {
+	case "create":
+		return executeGistCreate(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid gist command: '%s'", command))
+	}
+}
+
+func executeGistCreate(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("gist create", flag.ExitOnError)
+
+	public := flagSet.Bool("public", false, "make the gist public, gists are secret by default")
+	description := flagSet.String("description", "", "description of the gist")
+	filename := flagSet.String("filename", "stdin.txt", "name of the file read from stdin")
+	copyURL := flagSet.Bool("copy", false, "copy the url of the gist to the clipboard")
+
+	files := parseArgs(flagSet, args)
+
+	// Without files the content is read from stdin.
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	contents := make(map[string]github.GistFile)
+
+	for _, f := range files {
+		var data []byte
+		name := filepath.Base(f)
+
+		if f == "-" {
+			data, err = io.ReadAll(os.Stdin)
+			name = *filename
+		} else {
+			data, err = os.ReadFile(f)
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, exists := contents[name]; exists {
+			return fmt.Errorf("duplicate file name '%s', gists need unique file names", name)
+		}
+
+		contents[name] = github.GistFile{Content: string(data)}
+	}
+
+	loggerFrom(ctx).Debug("gist create", "files", files, "public", *public)
+
+	created, err := githubClient().CreateGist(ctx, &github.GistRequest{
+		Description: *description,
+		Public:      *public,
+		Files:       contents,
+	})
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	fmt.Println(created.HTMLURL)
+
+	if *copyURL {
+		err := copyToClipboard(ctx, created.HTMLURL)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Copied the url to the clipboard")
+	}
+
+	return nil
+}
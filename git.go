@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// runGit runs git with args in the current directory, its output goes
+// straight to stderr so stdout only carries the results, e.g. the
+// directories of repo clone in a pipeline.
+func runGit(ctx context.Context, args ...string) error {
+	loggerFrom(ctx).Debug("git", "args", args)
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("'git %s' failed: %v", strings.Join(args, " "), err)
+	}
+
+	return nil
+}
+
+// runGitQuiet runs git with args discarding its output, for checks where
+// only the exit status matters.
+func runGitQuiet(ctx context.Context, args ...string) error {
+	loggerFrom(ctx).Debug("git", "args", args)
+
+	return exec.Command("git", args...).Run()
+}
+
+// originRepo returns the owner/name of the origin remote of the git
+// repository in the current directory.
+func originRepo() (string, error) {
+	out, err := exec.Command("git", "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", err
+	}
+
+	owner, name, err := parseRepoName(strings.TrimSpace(string(out)))
+	if err != nil {
+		return "", err
+	}
+
+	return owner + "/" + name, nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+const githubAPI = github.DefaultBaseURL
+
+// errNotFound is returned when github responds with a 404 for the requested resource.
+var errNotFound = errors.New("not found on github")
+
+// errForbidden is returned when github denies access to the requested resource.
+var errForbidden = errors.New("github denied access, check the permissions of your token")
+
+// githubToken returns the token used to authenticate against github, read
+// from the GITHUB_TOKEN or GH_TOKEN environment variables.
+func githubToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+
+	return os.Getenv("GH_TOKEN")
+}
+
+// requireToken returns an error when no github token is configured, for
+// commands that only work authenticated.
+func requireToken() error {
+	if githubToken() == "" {
+		return &githubError{Category: categoryAuth, Message: "this command requires authentication, set the GITHUB_TOKEN environment variable"}
+	}
+
+	return nil
+}
+
+// newGithubRequest prepares a request against the github api. The path is
+// relative to the api root, e.g. "/repos/golang/go".
+func newGithubRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	var payload interface{}
+	if body != nil {
+		payload = body
+	}
+
+	return githubClient().NewRequest(ctx, method, path, query, payload)
+}
+
+// githubClient returns a client of the github api sending its requests
+// through the transports of the default http client, so they are logged,
+// retried, traced and replayed like any other.
+func githubClient() *github.Client {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return github.NewClient(github.Options{
+		BaseURL:   githubAPI,
+		Token:     githubToken(),
+		Transport: &githubTransport{next: next},
+	})
+}
+
+// githubTransport refuses the requests of the github client in offline mode
+// and shows a spinner while waiting for github to answer. Bodies are read
+// after it returns, so streamed downloads show their own progress.
+type githubTransport struct {
+	next http.RoundTripper
+}
+
+func (t *githubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if *offline {
+		return nil, errOffline
+	}
+
+	spin := startSpinner(req.Method + " " + req.URL.Path)
+	defer spin.Stop()
+
+	return t.next.RoundTrip(req)
+}
+
+// pageOptions controls how many results are fetched from paginated endpoints.
+type pageOptions struct {
+	limit int
+	all   bool
+}
+
+// addPageFlags registers the shared --limit, --all and --resume flags on the
+// flag set.
+func addPageFlags(flagSet *flag.FlagSet) *pageOptions {
+	opts := &pageOptions{}
+
+	flagSet.IntVar(&opts.limit, "limit", 30, "maximum number of results to fetch")
+	flagSet.BoolVar(&opts.all, "all", false, "fetch all results, ignoring --limit")
+	flagSet.BoolVar(&resumePages, "resume", false, "with --all, continue where an interrupted run of the same command stopped")
+
+	return opts
+}
+
+// max returns the maximum number of results to fetch, zero means no limit.
+func (p *pageOptions) max() int {
+	if p.all {
+		return 0
+	}
+
+	return p.limit
+}
+
+// collectPages fetches pages until limit items keep returns true for are
+// collected, a limit of zero fetches every page and a nil keep collects
+// every item.
+//
+// Fetching every page checkpoints the cursor after each page so an
+// interrupted run can be continued with --resume.
+func collectPages[T any](ctx context.Context, pages *github.Pages[T], limit int, keep func(T) bool) ([]T, error) {
+	// Filtered items can not bound the number of pages.
+	perPage := 0
+	if keep == nil {
+		perPage = 100
+		if limit > 0 && limit < perPage {
+			perPage = limit
+		}
+		pages.PerPage(perPage)
+	}
+
+	defer func() {
+		skippedResults(ctx, pages.Skipped())
+	}()
+
+	items := make([]T, 0)
+
+	checkpoint := limit == 0
+	key := cursorKey(pages.URL())
+
+	if checkpoint && resumePages {
+		cursor, ok := loadCursor(ctx, key)
+		if ok && json.Unmarshal(cursor.Items, &items) == nil && pages.Resume(cursor.Next) == nil {
+			loggerFrom(ctx).Debug("cursor: resuming", "next", cursor.Next, "items", len(items))
+			fmt.Fprintf(os.Stderr, "Resuming with %d results fetched %s ago\n", len(items), formatAge(cursor.SavedAt))
+		} else {
+			items = make([]T, 0)
+		}
+	}
+
+	progress := newPageProgress()
+	defer progress.Finish()
+
+	for {
+		page, err := pages.Next()
+		if err != nil {
+			if checkpoint && len(items) > 0 {
+				fmt.Fprintf(os.Stderr, "Fetched %d results before failing, run the command again with --resume to continue\n", len(items))
+			}
+			return nil, requestError(ctx, err)
+		}
+
+		for _, item := range page {
+			if keep == nil || keep(item) {
+				items = append(items, item)
+			}
+		}
+
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+
+		if pages.Done() {
+			if checkpoint {
+				removeCursor(ctx, key)
+			}
+			return items, nil
+		}
+
+		if checkpoint {
+			saveCursor(ctx, key, pages.URL(), items)
+		}
+
+		progress.update(pages.Response().Header, len(items), limit, perPage)
+	}
+}
+
+// skippedResults warns of items github sent that the client left out
+// because they did not decode, like a field of another type than
+// documented.
+func skippedResults(ctx context.Context, n int) {
+	if n > 0 {
+		loggerFrom(ctx).Warn("leaving out results github sent that could not be decoded", "count", n)
+	}
+}
+
+// githubPath returns the path of a github.com url, like
+// https://github.com/golang/go/tree/master, github.com/golang/go or
+// git@github.com:golang/go.git, without the leading slash. ok is false for
+// anything else.
+func githubPath(arg string) (string, bool) {
+	if path, found := strings.CutPrefix(arg, "git@github.com:"); found {
+		return path, true
+	}
+
+	if strings.HasPrefix(arg, "github.com/") || strings.HasPrefix(arg, "www.github.com/") {
+		arg = "https://" + arg
+	}
+
+	u, err := url.Parse(arg)
+	if err != nil || u.Scheme == "" || (u.Host != "github.com" && u.Host != "www.github.com") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(u.Path, "/"), true
+}
+
+// parseRepoName splits an "owner/name" argument into its parts. The urls of
+// repositories, of pages within them and git remotes are accepted too.
+func parseRepoName(arg string) (string, string, error) {
+	repo := strings.TrimSpace(arg)
+
+	if path, ok := githubPath(repo); ok {
+		parts := strings.SplitN(path, "/", 3)
+		if len(parts) >= 2 {
+			repo = parts[0] + "/" + strings.TrimSuffix(parts[1], ".git")
+		}
+	}
+
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", usageErrorf("invalid repository '%s', expected owner/name", arg)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// parseLogin returns the login of a user or organization given as a login,
+// @login or the url of the profile.
+func parseLogin(arg string) (string, error) {
+	login := strings.TrimPrefix(strings.TrimSpace(arg), "@")
+
+	if path, ok := githubPath(login); ok {
+		login, _, _ = strings.Cut(path, "/")
+	}
+
+	if login == "" || strings.ContainsAny(login, "/ ?#") {
+		return "", usageErrorf("invalid login '%s'", arg)
+	}
+
+	return login, nil
+}
@@ -0,0 +1,216 @@
+package main
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative searchpb/search.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/searchpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcSearchServer implements the Search service of searchpb on top of the
+// cache, rate limit and api key of the http server.
+type grpcSearchServer struct {
+	searchpb.UnimplementedSearchServer
+
+	s *searchServer
+}
+
+// newGRPCServer returns a grpc server with the Search service registered.
+func (s *searchServer) newGRPCServer() *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(s.grpcInterceptor))
+	searchpb.RegisterSearchServer(server, &grpcSearchServer{s: s})
+
+	return server
+}
+
+// grpcInterceptor checks the api key sent in the authorization metadata and
+// logs the calls like the http requests, with the x-request-id and
+// traceparent metadata taking the place of the headers.
+func (s *searchServer) grpcInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if !s.authorized(md.Get("authorization")) {
+		fmt.Fprintf(os.Stderr, "%s GRPC %s %s\n", time.Now().Format(time.RFC3339), info.FullMethod, codes.Unauthenticated)
+		return nil, status.Error(codes.Unauthenticated, "missing or invalid api key")
+	}
+
+	start := time.Now()
+
+	sr := startServeRequest(info.FullMethod, firstValue(md.Get("x-request-id")), firstValue(md.Get("traceparent")))
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", sr.id))
+
+	res, err := handler(withServeRequest(withLogger(ctx, s.log), sr), req)
+
+	fmt.Fprintf(os.Stderr, "%s GRPC %s %s %s %s\n", start.Format(time.RFC3339), info.FullMethod, status.Code(err), time.Since(start).Round(time.Millisecond), sr.id)
+
+	if sr.span != nil {
+		sr.span.attrs["rpc.system"] = "grpc"
+		sr.span.attrs["rpc.grpc.status_code"] = int(status.Code(err))
+		tracing.end(sr.span, err)
+	}
+
+	metrics.inc("gcf_requests_total", "api", "grpc", "handler", info.FullMethod, "code", status.Code(err).String())
+	metrics.observe("gcf_request_duration_seconds", time.Since(start), "api", "grpc", "handler", info.FullMethod)
+
+	return res, err
+}
+
+// cachedCall answers from the cache under key when possible, or runs call
+// within the rate limit and caches its response.
+func (g *grpcSearchServer) cachedCall(ctx context.Context, key string, out proto.Message, call func() (proto.Message, error)) error {
+	if body, ok := g.s.cached(key); ok {
+		return proto.Unmarshal(body, out)
+	}
+
+	if wait, ok := g.s.allowFetch(); !ok {
+		return status.Errorf(codes.ResourceExhausted, "too many searches, try again in %s", wait.Round(time.Second))
+	}
+
+	var res proto.Message
+
+	err := g.s.fetch(ctx, func() error {
+		var err error
+		res, err = call()
+		return err
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+
+	body, err := proto.Marshal(res)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	g.s.store(key, body)
+
+	return proto.Unmarshal(body, out)
+}
+
+func (g *grpcSearchServer) SearchRepos(ctx context.Context, req *searchpb.SearchReposRequest) (*searchpb.SearchReposResponse, error) {
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "provide a search query")
+	}
+
+	res := &searchpb.SearchReposResponse{}
+
+	err := g.cachedCall(ctx, "grpc SearchRepos "+req.Sort+" "+req.Query, res, func() (proto.Message, error) {
+		repos, err := searchRepositories(ctx, req.Query, req.Sort)
+		if err != nil {
+			return nil, err
+		}
+
+		out := &searchpb.SearchReposResponse{}
+		for _, r := range repos {
+			out.Repositories = append(out.Repositories, repositoryMessage(r))
+		}
+
+		return out, nil
+	})
+
+	return res, err
+}
+
+func (g *grpcSearchServer) SearchUsers(ctx context.Context, req *searchpb.SearchUsersRequest) (*searchpb.SearchUsersResponse, error) {
+	if req.Query == "" {
+		return nil, status.Error(codes.InvalidArgument, "provide a search query")
+	}
+
+	res := &searchpb.SearchUsersResponse{}
+
+	err := g.cachedCall(ctx, "grpc SearchUsers "+req.Sort+" "+req.Query, res, func() (proto.Message, error) {
+		logins, err := findUsers(ctx, req.Query, req.Sort)
+		if err != nil {
+			return nil, err
+		}
+
+		out := &searchpb.SearchUsersResponse{}
+		for _, login := range logins {
+			out.Users = append(out.Users, &searchpb.User{Login: login, HtmlUrl: "https://github.com/" + login})
+		}
+
+		return out, nil
+	})
+
+	return res, err
+}
+
+func (g *grpcSearchServer) GetRepo(ctx context.Context, req *searchpb.GetRepoRequest) (*searchpb.Repository, error) {
+	owner, name, err := parseRepoName(req.FullName)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	res := &searchpb.Repository{}
+
+	err = g.cachedCall(ctx, "grpc GetRepo "+strings.ToLower(req.FullName), res, func() (proto.Message, error) {
+		repo, err := githubClient().GetRepository(ctx, owner, name)
+		if err != nil {
+			return nil, requestError(ctx, err)
+		}
+
+		indexRepositories(ctx, []repository{*repo})
+
+		return repositoryMessage(*repo), nil
+	})
+
+	return res, err
+}
+
+// firstValue returns the first of the values of a metadata key, if any.
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func repositoryMessage(r repository) *searchpb.Repository {
+	m := &searchpb.Repository{
+		FullName:      r.FullName,
+		Description:   r.Description,
+		Language:      r.Language,
+		Topics:        r.Topics,
+		Stars:         int64(r.StargazersCount),
+		Forks:         int64(r.ForksCount),
+		Fork:          r.Fork,
+		Private:       r.Private,
+		Archived:      r.Archived,
+		HtmlUrl:       r.HTMLURL,
+		DefaultBranch: r.DefaultBranch,
+	}
+
+	if !r.PushedAt.IsZero() {
+		m.PushedAt = timestamppb.New(r.PushedAt)
+	}
+
+	return m
+}
+
+// grpcError maps the errors of the github requests to a status, like
+// errorStatus does for http.
+func grpcError(err error) error {
+	switch {
+	case errorCategory(err) == categoryRateLimited:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, errNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var historyUsage = `Specify a history command to execute:
+  - list: List the executed commands
+  - search: Search the executed commands
+  - run: Run a command from the history again`
+
+// historyEntry is an executed command, the arguments include the top level
+// flags so it can be run again as it was. Secrets, like the values of
+// --secret or --header, are recorded as "***".
+type historyEntry struct {
+	Time time.Time `json:"time"`
+	Args []string  `json:"args"`
+}
+
+func (e historyEntry) command() string {
+	quoted := make([]string, len(e.Args))
+
+	for i, arg := range e.Args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'$*?<>|&;") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func historyPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// recordHistory appends the command to the history file unless the history
+// is turned off in the config. Failing to record is not worth failing the
+// command for, it only shows up in the debug output.
+func recordHistory(ctx context.Context, args []string) {
+	cfg, err := loadConfig(ctx)
+	if err != nil || !cfg.History {
+		return
+	}
+
+	path, err := historyPath()
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o700)
+	}
+
+	var file *os.File
+	if err == nil {
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	}
+
+	if err == nil {
+		err = json.NewEncoder(file).Encode(historyEntry{Time: time.Now(), Args: args})
+
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if err != nil {
+		loggerFrom(ctx).Debug("recording history failed", "err", err)
+	}
+}
+
+func readHistory() ([]historyEntry, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]historyEntry, 0)
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		entry := historyEntry{}
+
+		// Skip lines cut short by an interrupted write instead of failing.
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+func executeHistory(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(historyUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("history", "command", command)
+
+	switch command {
+	case "list", "search":
+		return executeHistoryList(command, args[1:])
+	case "run":
+		return executeHistoryRun(args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid history command: '%s'", command))
+	}
+}
+
+// executeHistoryList lists the history entries, search only lists the ones
+// containing a term. Entries are numbered from the oldest so the numbers
+// stay the same as the history grows.
+func executeHistoryList(command string, args []string) error {
+	flagSet := flag.NewFlagSet("history "+command, flag.ExitOnError)
+
+	limit := flagSet.Int("limit", 30, "number of most recent entries to show, 0 shows all")
+
+	args = parseArgs(flagSet, args)
+
+	term := ""
+	if command == "search" {
+		args = promptMissing(args, "Search term")
+
+		if len(args) == 0 {
+			return usageError("provide a search term: history search <term>")
+		}
+
+		term = args[0]
+	}
+
+	entries, err := readHistory()
+	if err != nil {
+		return err
+	}
+
+	type numberedEntry struct {
+		Number int `json:"number"`
+		historyEntry
+	}
+
+	matches := make([]numberedEntry, 0)
+
+	for i, e := range entries {
+		if term == "" || strings.Contains(strings.ToLower(e.command()), strings.ToLower(term)) {
+			matches = append(matches, numberedEntry{i + 1, e})
+		}
+	}
+
+	if *limit > 0 && len(matches) > *limit {
+		matches = matches[len(matches)-*limit:]
+	}
+
+	if jsonOutput() {
+		return printJSON(matches)
+	}
+
+	for _, m := range matches {
+		fmt.Printf("%5d  %s  %s\n", m.Number, m.Time.Local().Format("2006-01-02 15:04"), m.command())
+	}
+
+	return nil
+}
+
+func executeHistoryRun(args []string) error {
+	flagSet := flag.NewFlagSet("history run", flag.ExitOnError)
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) == 0 {
+		return usageError("provide an entry: history run <number>")
+	}
+
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return usageErrorf("invalid history entry '%s'", args[0])
+	}
+
+	entries, err := readHistory()
+	if err != nil {
+		return err
+	}
+
+	if number < 1 || number > len(entries) {
+		return fmt.Errorf("no history entry %d", number)
+	}
+
+	entry := entries[number-1]
+
+	if len(entry.Args) == 0 {
+		return fmt.Errorf("history entry %d has no command", number)
+	}
+
+	for _, arg := range entry.Args {
+		if strings.Contains(arg, "***") {
+			return fmt.Errorf("history entry %d contained secrets, which are not recorded, run it again with them: %s", number, entry.command())
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, entry.command())
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// The entry runs as a new process so its top level flags, like -dry-run
+	// or -deadline, set up the transports as in the original run. Those of
+	// this run come first, the entry overrides them but cannot drop them.
+	topLevel := os.Args[1 : len(os.Args)-len(flag.Args())]
+	runArgs := append(append([]string{}, topLevel...), entry.Args...)
+
+	cmd := exec.Command(exe, runArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return exitStatus(exit.ExitCode())
+	}
+
+	return err
+}
+
+// exitStatus is the failure of a command run as a child process, which
+// printed its error already. main exits with the same code.
+type exitStatus int
+
+func (s exitStatus) Error() string {
+	return fmt.Sprintf("exit status %d", int(s))
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// indexMigrations are the schema versions of the local index, the position
+// in the list is the version recorded in the user_version pragma. New
+// versions are appended, released ones are never changed.
+var indexMigrations = []string{
+	`CREATE TABLE repositories (
+		full_name   TEXT PRIMARY KEY,
+		description TEXT NOT NULL,
+		language    TEXT NOT NULL,
+		topics      TEXT NOT NULL,
+		stars       INTEGER NOT NULL,
+		forks       INTEGER NOT NULL,
+		fork        INTEGER NOT NULL,
+		archived    INTEGER NOT NULL,
+		html_url    TEXT NOT NULL,
+		pushed_at   TEXT NOT NULL,
+		fetched_at  TEXT NOT NULL
+	);
+	CREATE TABLE repository_snapshots (
+		full_name  TEXT NOT NULL,
+		stars      INTEGER NOT NULL,
+		forks      INTEGER NOT NULL,
+		fetched_at TEXT NOT NULL
+	);
+	CREATE INDEX repository_snapshots_full_name ON repository_snapshots (full_name, fetched_at);
+	CREATE TABLE users (
+		login      TEXT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		bio        TEXT NOT NULL,
+		html_url   TEXT NOT NULL,
+		fetched_at TEXT NOT NULL
+	);`,
+	// Full text search tables kept in sync with triggers, see local search.
+	`CREATE VIRTUAL TABLE repositories_fts USING fts5(full_name, description, topics, content='repositories');
+	CREATE TRIGGER repositories_fts_insert AFTER INSERT ON repositories BEGIN
+		INSERT INTO repositories_fts (rowid, full_name, description, topics) VALUES (new.rowid, new.full_name, new.description, new.topics);
+	END;
+	CREATE TRIGGER repositories_fts_update AFTER UPDATE ON repositories BEGIN
+		INSERT INTO repositories_fts (repositories_fts, rowid, full_name, description, topics) VALUES ('delete', old.rowid, old.full_name, old.description, old.topics);
+		INSERT INTO repositories_fts (rowid, full_name, description, topics) VALUES (new.rowid, new.full_name, new.description, new.topics);
+	END;
+	CREATE TRIGGER repositories_fts_delete AFTER DELETE ON repositories BEGIN
+		INSERT INTO repositories_fts (repositories_fts, rowid, full_name, description, topics) VALUES ('delete', old.rowid, old.full_name, old.description, old.topics);
+	END;
+	INSERT INTO repositories_fts (repositories_fts) VALUES ('rebuild');
+	CREATE VIRTUAL TABLE users_fts USING fts5(login, name, bio, content='users');
+	CREATE TRIGGER users_fts_insert AFTER INSERT ON users BEGIN
+		INSERT INTO users_fts (rowid, login, name, bio) VALUES (new.rowid, new.login, new.name, new.bio);
+	END;
+	CREATE TRIGGER users_fts_update AFTER UPDATE ON users BEGIN
+		INSERT INTO users_fts (users_fts, rowid, login, name, bio) VALUES ('delete', old.rowid, old.login, old.name, old.bio);
+		INSERT INTO users_fts (rowid, login, name, bio) VALUES (new.rowid, new.login, new.name, new.bio);
+	END;
+	CREATE TRIGGER users_fts_delete AFTER DELETE ON users BEGIN
+		INSERT INTO users_fts (users_fts, rowid, login, name, bio) VALUES ('delete', old.rowid, old.login, old.name, old.bio);
+	END;
+	INSERT INTO users_fts (users_fts) VALUES ('rebuild');`,
+}
+
+func indexPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "index.db"), nil
+}
+
+// openIndex opens the local index of fetched repositories and users,
+// creating it or migrating it to the latest schema version first.
+func openIndex(ctx context.Context) (*sql.DB, error) {
+	path, err := indexPath()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commands running at the same time wait for each other's writes.
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+
+	err = migrateIndex(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// migrateIndex applies the migrations newer than the version of the index.
+func migrateIndex(ctx context.Context, db *sql.DB) error {
+	version := 0
+
+	err := db.QueryRow("PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	if version > len(indexMigrations) {
+		return fmt.Errorf("index schema version %d is newer than this version of go-cli-flag supports", version)
+	}
+
+	for ; version < len(indexMigrations); version++ {
+		loggerFrom(ctx).Debug("index: migrating", "version", version+1)
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(indexMigrations[version])
+		if err == nil {
+			// Pragmas do not take parameters.
+			_, err = tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", version+1))
+		}
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateIndex runs update in a transaction on the index unless indexing is
+// turned off in the config. Like the history, failing to index is not worth
+// failing the command for, it only shows up in the debug output.
+func updateIndex(ctx context.Context, update func(tx *sql.Tx, fetchedAt string) error) {
+	cfg, err := loadConfig(ctx)
+	if err != nil || !cfg.Index {
+		return
+	}
+
+	db, err := openIndex(ctx)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err == nil {
+		err = update(tx, indexTime(time.Now()))
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+	}
+}
+
+// indexTime formats t for the index, unknown times are stored empty.
+func indexTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// indexRepositories stores the repositories in the local index. Every fetch
+// also adds a snapshot of the stars and forks to follow them over time.
+func indexRepositories(ctx context.Context, repos []repository) {
+	if len(repos) == 0 {
+		return
+	}
+
+	updateIndex(ctx, func(tx *sql.Tx, fetchedAt string) error {
+		for _, r := range repos {
+			if r.FullName == "" {
+				continue
+			}
+
+			_, err := tx.Exec(`INSERT INTO repositories
+				(full_name, description, language, topics, stars, forks, fork, archived, html_url, pushed_at, fetched_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (full_name) DO UPDATE SET
+					description = excluded.description,
+					language = excluded.language,
+					topics = excluded.topics,
+					stars = excluded.stars,
+					forks = excluded.forks,
+					fork = excluded.fork,
+					archived = excluded.archived,
+					html_url = excluded.html_url,
+					pushed_at = excluded.pushed_at,
+					fetched_at = excluded.fetched_at`,
+				r.FullName, r.Description, r.Language, strings.Join(r.Topics, " "), r.StargazersCount, r.ForksCount,
+				r.Fork, r.Archived, r.HTMLURL, indexTime(r.PushedAt), fetchedAt)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(`INSERT INTO repository_snapshots (full_name, stars, forks, fetched_at) VALUES (?, ?, ?, ?)`,
+				r.FullName, r.StargazersCount, r.ForksCount, fetchedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		loggerFrom(ctx).Debug("index: indexed", "repositories", len(repos))
+
+		return nil
+	})
+}
+
+// indexUsers stores the users in the local index. Lists of users only carry
+// the login, the name and bio of a user seen in full before are kept.
+func indexUsers(ctx context.Context, users []userProfile) {
+	if len(users) == 0 {
+		return
+	}
+
+	updateIndex(ctx, func(tx *sql.Tx, fetchedAt string) error {
+		for _, u := range users {
+			if u.Login == "" {
+				continue
+			}
+
+			_, err := tx.Exec(`INSERT INTO users (login, name, bio, html_url, fetched_at)
+				VALUES (?, ?, ?, ?, ?)
+				ON CONFLICT (login) DO UPDATE SET
+					name = coalesce(nullif(excluded.name, ''), users.name),
+					bio = coalesce(nullif(excluded.bio, ''), users.bio),
+					html_url = excluded.html_url,
+					fetched_at = excluded.fetched_at`,
+				u.Login, u.Name, u.Bio, u.HTMLURL, fetchedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		loggerFrom(ctx).Debug("index: indexed", "users", len(users))
+
+		return nil
+	})
+}
+
+// indexUserSummaries stores the users of a user list in the local index.
+func indexUserSummaries(ctx context.Context, users []userSummary) {
+	profiles := make([]userProfile, len(users))
+
+	for i, u := range users {
+		profiles[i] = userProfile{Login: u.Login, HTMLURL: u.HTMLURL}
+	}
+
+	indexUsers(ctx, profiles)
+}
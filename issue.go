@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var issueUsage = `Specify an issue command to execute:
+  - create: Create an issue
+  - list: List the issues of a repository`
+
+func executeIssue(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(issueUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("issue", "command", command)
+
+	switch command {
+	case "create":
+		return executeIssueCreate(ctx, args[1:])
+	case "list":
+		return executeIssueList(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid issue command: '%s'", command))
+	}
+}
+
+type label = github.Label
+
+type issue = github.Issue
+
+func executeIssueCreate(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("issue create", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to create the issue in, owner/name")
+	title := flagSet.String("title", "", "title of the issue")
+	body := flagSet.String("body", "", "body of the issue, opens $EDITOR when neither --body nor --body-file is given")
+	bodyFile := flagSet.String("body-file", "", "read the body from a file, - reads stdin")
+	labels := stringList{}
+	assignees := stringList{}
+	flagSet.Var(&labels, "label", "label to add, can be repeated")
+	flagSet.Var(&assignees, "assignee", "user to assign, can be repeated")
+
+	parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil {
+		return usageError("provide a repository: issue create --repo <owner/name> --title <title>")
+	}
+
+	if *title == "" {
+		return usageError("provide a title: issue create --repo <owner/name> --title <title>")
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *bodyFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		*body = string(data)
+	case *bodyFile != "":
+		data, err := os.ReadFile(*bodyFile)
+		if err != nil {
+			return err
+		}
+		*body = string(data)
+	case *body == "":
+		*body, err = editText(ctx, "Write the body of the issue above the line, everything below it is ignored.")
+		if err != nil {
+			return err
+		}
+	}
+
+	loggerFrom(ctx).Debug("issue create", "repo", owner+"/"+name, "title", *title, "labels", labels, "assignees", assignees)
+
+	created, err := githubClient().CreateIssue(ctx, owner, name, &github.IssueRequest{
+		Title:     *title,
+		Body:      *body,
+		Labels:    labels,
+		Assignees: assignees,
+	})
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	fmt.Println(created.HTMLURL)
+
+	return nil
+}
+
+func executeIssueList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("issue list", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to list the issues of, owner/name")
+	state := flagSet.String("state", "open", "only show issues in a state: open, closed or all")
+	assignee := flagSet.String("assignee", "", "only show issues assigned to a user, @me for yourself")
+	web := flagSet.Bool("web", false, "open the filtered issues in the browser")
+	labels := stringList{}
+	flagSet.Var(&labels, "label", "only show issues with a label, can be repeated")
+	page := addPageFlags(flagSet)
+
+	parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil {
+		return usageError("provide a repository: issue list --repo <owner/name>")
+	}
+
+	loggerFrom(ctx).Debug("issue list", "repo", owner+"/"+name, "state", *state, "labels", labels, "assignee", *assignee)
+
+	if *web {
+		return openBrowser(ctx, issuesWebURL(owner, name, *state, labels, *assignee))
+	}
+
+	opts := &github.IssueListOptions{State: *state, Labels: labels, Assignee: *assignee}
+
+	// The rest api does not understand @me like the web search does.
+	if opts.Assignee == "@me" {
+		opts.Assignee, err = findAuthenticatedLogin(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Pull requests are issues as well for the api, they are skipped.
+	issues, err := collectPages(ctx, githubClient().ListIssues(ctx, owner, name, opts), page.max(), func(i issue) bool {
+		return !i.IsPullRequest()
+	})
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(issues)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NUMBER\tTITLE\tLABELS\tASSIGNEES\tUPDATED")
+
+	for _, i := range issues {
+		labelNames := make([]string, 0, len(i.Labels))
+		for _, l := range i.Labels {
+			labelNames = append(labelNames, l.Name)
+		}
+
+		assigneeLogins := make([]string, 0, len(i.Assignees))
+		for _, a := range i.Assignees {
+			assigneeLogins = append(assigneeLogins, a.Login)
+		}
+
+		fmt.Fprintf(w, "#%d\t%s\t%s\t%s\t%s\n", i.Number, truncate(i.Title, 60), strings.Join(labelNames, ", "), strings.Join(assigneeLogins, ", "), formatDate(i.UpdatedAt))
+	}
+
+	return w.Flush()
+}
+
+// issuesWebURL builds the url of the issues page of a repository searching
+// for the same filters as issue list.
+func issuesWebURL(owner, name, state string, labels []string, assignee string) string {
+	terms := []string{"is:issue"}
+
+	if state != "all" {
+		terms = append(terms, "is:"+state)
+	}
+
+	for _, l := range labels {
+		terms = append(terms, fmt.Sprintf("label:%q", l))
+	}
+
+	if assignee != "" {
+		terms = append(terms, "assignee:"+assignee)
+	}
+
+	query := url.Values{}
+	query.Set("q", strings.Join(terms, " "))
+
+	return fmt.Sprintf("https://github.com/%s/%s/issues?%s", owner, name, query.Encode())
+}
+
+// findAuthenticatedLogin returns the login of the user the token belongs to.
+func findAuthenticatedLogin(ctx context.Context) (string, error) {
+	err := requireToken()
+	if err != nil {
+		return "", err
+	}
+
+	user, err := githubClient().GetAuthenticatedUser(ctx)
+	if err != nil {
+		return "", requestError(ctx, err)
+	}
+
+	return user.Login, nil
+}
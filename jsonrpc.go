@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Error codes of the JSON-RPC 2.0 specification.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// rpcHandler answers a request, the params are the raw json sent.
+type rpcHandler func(ctx context.Context, method string, params json.RawMessage) (interface{}, *rpcError)
+
+// serveJSONRPC reads newline delimited JSON-RPC 2.0 requests from in and
+// writes a response line per request to out, until in is closed.
+// Notifications, requests without an id, are handled without a response.
+func serveJSONRPC(ctx context.Context, in io.Reader, out io.Writer, handle rpcHandler) error {
+	reader := bufio.NewReader(in)
+	encoder := json.NewEncoder(out)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(bytes.TrimSpace(line)) == 0 {
+			return nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		res, ok := handleJSONRPC(ctx, line, handle)
+		if ok {
+			err = encoder.Encode(res)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleJSONRPC answers a single request line, ok is false for
+// notifications.
+func handleJSONRPC(ctx context.Context, line []byte, handle rpcHandler) (rpcResponse, bool) {
+	res := rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null")}
+
+	req := rpcRequest{}
+
+	err := json.Unmarshal(line, &req)
+	if err != nil {
+		res.Error = &rpcError{Code: rpcParseError, Message: "invalid json"}
+		return res, true
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		res.Error = &rpcError{Code: rpcInvalidRequest, Message: "not a json-rpc 2.0 request"}
+		return res, true
+	}
+
+	result, rpcErr := handle(ctx, req.Method, req.Params)
+
+	if len(req.ID) == 0 {
+		return res, false
+	}
+
+	res.ID = req.ID
+
+	if rpcErr != nil {
+		res.Error = rpcErr
+		return res, true
+	}
+
+	// A null result would be left out, but responses need one of result
+	// and error.
+	if result == nil {
+		result = struct{}{}
+	}
+	res.Result = result
+
+	return res, true
+}
+
+// decodeParams decodes the params of a request into out, no params leave
+// out as is.
+func decodeParams(params json.RawMessage, out interface{}) *rpcError {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+
+	err := json.Unmarshal(params, out)
+	if err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+
+	return nil
+}
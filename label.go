@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+var labelUsage = `Specify a label command to execute:
+  - list: List the labels of a repository
+  - create: Create a label
+  - delete: Delete a label
+  - clone: Copy the labels of another repository`
+
+func executeLabel(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(labelUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("label", "command", command)
+
+	switch command {
+	case "list":
+		return executeLabelList(ctx, args[1:])
+	case "create":
+		return executeLabelCreate(ctx, args[1:])
+	case "delete":
+		return executeLabelDelete(ctx, args[1:])
+	case "clone":
+		return executeLabelClone(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid label command: '%s'", command))
+	}
+}
+
+func findLabels(ctx context.Context, owner, name string) ([]label, error) {
+	return collectPages(ctx, githubClient().ListLabels(ctx, owner, name), 0, nil)
+}
+
+func executeLabelList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("label list", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to list the labels of, owner/name")
+
+	parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil {
+		return usageError("provide a repository: label list --repo <owner/name>")
+	}
+
+	loggerFrom(ctx).Debug("label list", "repo", owner+"/"+name)
+
+	labels, err := findLabels(ctx, owner, name)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(labels)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCOLOR\tDESCRIPTION")
+
+	for _, l := range labels {
+		fmt.Fprintf(w, "%s\t#%s\t%s\n", l.Name, l.Color, l.Description)
+	}
+
+	return w.Flush()
+}
+
+func executeLabelCreate(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("label create", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to create the label in, owner/name")
+	color := flagSet.String("color", "ededed", "hex color of the label, e.g. d73a4a")
+	description := flagSet.String("description", "", "description of the label")
+
+	args = parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil || len(args) == 0 {
+		return usageError("provide a repository and name: label create <name> --repo <owner/name>")
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	l := label{
+		Name:        args[0],
+		Color:       strings.TrimPrefix(*color, "#"),
+		Description: *description,
+	}
+
+	loggerFrom(ctx).Debug("label create", "repo", owner+"/"+name, "label", l.Name)
+
+	created, err := githubClient().CreateLabel(ctx, owner, name, l)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	fmt.Printf("Created label %s in %s/%s\n", created.Name, owner, name)
+
+	return nil
+}
+
+func executeLabelDelete(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("label delete", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to delete the label from, owner/name")
+	yes := flagSet.Bool("yes", false, "delete without asking for confirmation")
+
+	args = parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil || len(args) == 0 {
+		return usageError("provide a repository and name: label delete <name> --repo <owner/name>")
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("label delete", "repo", owner+"/"+name, "label", args[0])
+
+	err = confirm(fmt.Sprintf("Delete label %s from %s/%s?", args[0], owner, name), *yes)
+	if err != nil {
+		return err
+	}
+
+	err = requestError(ctx, githubClient().DeleteLabel(ctx, owner, name, args[0]))
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("label '%s' not found in %s/%s", args[0], owner, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted label %s from %s/%s\n", args[0], owner, name)
+
+	return nil
+}
+
+// executeLabelClone creates the labels of a source repository in the target
+// repository. Labels the target already has are left alone unless --force is
+// given, then their color and description are updated to match the source.
+func executeLabelClone(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("label clone", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to copy the labels to, owner/name")
+	force := flagSet.Bool("force", false, "update labels that already exist in the target repository")
+
+	args = parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil || len(args) == 0 {
+		return usageError("provide the source and target repository: label clone <source/repo> --repo <owner/name>")
+	}
+
+	sourceOwner, sourceName, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("label clone", "source", sourceOwner+"/"+sourceName, "target", owner+"/"+name, "force", *force)
+
+	source, err := findLabels(ctx, sourceOwner, sourceName)
+	if err != nil {
+		return err
+	}
+
+	target, err := findLabels(ctx, owner, name)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]label)
+	for _, l := range target {
+		existing[strings.ToLower(l.Name)] = l
+	}
+
+	client := githubClient()
+	created, updated, failed := 0, 0, 0
+
+	for _, l := range source {
+		current, ok := existing[strings.ToLower(l.Name)]
+
+		switch {
+		case !ok:
+			_, err = client.CreateLabel(ctx, owner, name, l)
+			if err == nil {
+				created++
+				fmt.Printf("Created %s\n", l.Name)
+			}
+		case *force && (current.Color != l.Color || current.Description != l.Description):
+			_, err = client.UpdateLabel(ctx, owner, name, current.Name, l)
+			if err == nil {
+				updated++
+				fmt.Printf("Updated %s\n", l.Name)
+			}
+		default:
+			loggerFrom(ctx).Debug("label clone: skipping", "label", l.Name)
+			continue
+		}
+
+		// Keep going so one bad label does not leave the copy half done.
+		err = requestError(ctx, err)
+		if err != nil && !errors.Is(err, errDryRun) {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", l.Name, err)
+		}
+	}
+
+	fmt.Printf("Created %d, updated %d labels in %s/%s\n", created, updated, owner, name)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to copy %d labels", failed)
+	}
+
+	return nil
+}
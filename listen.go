@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxWebhookPayload is the largest payload github sends, 25 MB.
+const maxWebhookPayload = 25 << 20
+
+// webhookDelivery is a received webhook as printed by listen.
+type webhookDelivery struct {
+	Event      string          `json:"event"`
+	Delivery   string          `json:"delivery"`
+	ReceivedAt time.Time       `json:"received_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// webhookListener validates the deliveries sent to it and hands them on.
+type webhookListener struct {
+	secret     string
+	forwardURL string
+	command    string
+	client     *http.Client
+
+	// Deliveries are printed and handed on one at a time, in order.
+	mu sync.Mutex
+}
+
+func executeListen(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("listen", flag.ExitOnError)
+
+	port := flagSet.Int("port", 8080, "port to receive webhooks on")
+	secret := flagSet.String("secret", os.Getenv("GCF_WEBHOOK_SECRET"), "secret of the webhook to validate signatures with, defaults to GCF_WEBHOOK_SECRET")
+	forwardURL := flagSet.String("forward-url", "", "post every delivery to a url instead of printing it")
+	command := flagSet.String("exec", "", "run a shell command for every delivery instead of printing it, with the delivery as json on stdin")
+
+	parseArgs(flagSet, args)
+
+	loggerFrom(ctx).Debug("listen", "port", *port, "forward_url", *forwardURL, "exec", *command)
+
+	if *secret == "" {
+		loggerFrom(ctx).Warn("no --secret, deliveries are accepted without checking their signature")
+	}
+
+	l := &webhookListener{
+		secret:     *secret,
+		forwardURL: *forwardURL,
+		command:    *command,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(*port))
+	if err != nil {
+		return err
+	}
+
+	// Deliveries are handled with the logger of the command, and finish when
+	// an interrupt shuts the server down.
+	base := context.WithoutCancel(ctx)
+
+	server := &http.Server{
+		Handler:           l,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return base },
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		server.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening for webhooks on %s\n", listener.Addr())
+
+	err = server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// validSignature checks the X-Hub-Signature-256 header github computes with
+// the secret of the webhook over the payload.
+func validSignature(secret string, payload []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+
+	sent, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hmac.Equal(sent, mac.Sum(nil))
+}
+
+func (l *webhookListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "webhooks are posted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayload+1))
+	if err != nil {
+		http.Error(w, "failed to read the payload", http.StatusBadRequest)
+		return
+	}
+
+	if len(payload) > maxWebhookPayload {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	delivery := webhookDelivery{
+		Event:      r.Header.Get("X-GitHub-Event"),
+		Delivery:   r.Header.Get("X-GitHub-Delivery"),
+		ReceivedAt: time.Now(),
+	}
+
+	if l.secret != "" && !validSignature(l.secret, payload, r.Header.Get("X-Hub-Signature-256")) {
+		loggerFrom(ctx).Warn("listen: rejected delivery, invalid signature", "delivery", delivery.Delivery)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	// Form encoded webhooks carry the json in the payload field.
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		r.Body = io.NopCloser(bytes.NewReader(payload))
+		payload = []byte(r.PostFormValue("payload"))
+	}
+
+	if !json.Valid(payload) {
+		http.Error(w, "payload is not json", http.StatusBadRequest)
+		return
+	}
+
+	delivery.Payload = payload
+
+	err = l.handle(ctx, delivery)
+	if err != nil {
+		loggerFrom(ctx).Error("listen: handling the delivery failed", "delivery", delivery.Delivery, "err", err)
+		http.Error(w, "failed to handle the delivery", http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handle prints the delivery as a json line, or hands it to the forward url
+// or command.
+func (l *webhookListener) handle(ctx context.Context, d webhookDelivery) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loggerFrom(ctx).Debug("listen", "event", d.Event, "delivery", d.Delivery)
+
+	line, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case l.forwardURL != "":
+		req, err := http.NewRequest(http.MethodPost, l.forwardURL, bytes.NewReader(d.Payload))
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-GitHub-Event", d.Event)
+		req.Header.Set("X-GitHub-Delivery", d.Delivery)
+
+		res, err := l.client.Do(req)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode >= 300 {
+			return fmt.Errorf("%s answered %s", l.forwardURL, res.Status)
+		}
+
+		return nil
+	case l.command != "":
+		shell := []string{"sh", "-c"}
+		if runtime.GOOS == "windows" {
+			shell = []string{"cmd", "/C"}
+		}
+
+		cmd := exec.Command(shell[0], append(shell[1:], l.command)...)
+		cmd.Stdin = bytes.NewReader(append(line, '\n'))
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), "GCF_EVENT="+d.Event, "GCF_DELIVERY="+d.Delivery)
+
+		return cmd.Run()
+	default:
+		_, err := fmt.Printf("%s\n", line)
+		return err
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// The example of the github documentation on validating deliveries.
+const (
+	exampleSecret    = "It's a Secret to Everybody"
+	examplePayload   = "Hello, World!"
+	exampleSignature = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+)
+
+func TestValidSignature(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		payload   string
+		signature string
+		want      bool
+	}{
+		{name: "documented example", secret: exampleSecret, payload: examplePayload, signature: exampleSignature, want: true},
+		{name: "other secret", secret: "It's a secret to nobody", payload: examplePayload, signature: exampleSignature},
+		{name: "other payload", secret: exampleSecret, payload: "Hello, World?", signature: exampleSignature},
+		{name: "uppercase digest", secret: exampleSecret, payload: examplePayload, signature: "sha256=" + strings.ToUpper(exampleSignature[7:]), want: true},
+		{name: "sha1 signature", secret: exampleSecret, payload: examplePayload, signature: "sha1=" + exampleSignature[7:]},
+		{name: "digest without prefix", secret: exampleSecret, payload: examplePayload, signature: exampleSignature[7:]},
+		{name: "not hex", secret: exampleSecret, payload: examplePayload, signature: "sha256=not-hex"},
+		{name: "cut short", secret: exampleSecret, payload: examplePayload, signature: exampleSignature[:40]},
+		{name: "missing", secret: exampleSecret, payload: examplePayload},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validSignature(tt.secret, []byte(tt.payload), tt.signature); got != tt.want {
+				t.Errorf("validSignature = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookListener(t *testing.T) {
+	forwarded := make(chan string, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		forwarded <- r.Header.Get("X-GitHub-Event") + " " + string(body)
+	}))
+	defer target.Close()
+
+	listener := &webhookListener{secret: exampleSecret, forwardURL: target.URL, client: target.Client()}
+
+	payload := `{"zen":"Keep it logically awesome."}`
+
+	tests := []struct {
+		name      string
+		method    string
+		signature string
+		status    int
+	}{
+		{name: "signed", method: http.MethodPost, signature: sign(exampleSecret, payload), status: http.StatusAccepted},
+		{name: "unsigned", method: http.MethodPost, status: http.StatusUnauthorized},
+		{name: "signed with another secret", method: http.MethodPost, signature: sign("guess", payload), status: http.StatusUnauthorized},
+		{name: "get", method: http.MethodGet, status: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-GitHub-Event", "ping")
+			if tt.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tt.signature)
+			}
+
+			rec := httptest.NewRecorder()
+			listener.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+
+			if tt.status != http.StatusAccepted {
+				return
+			}
+
+			if got := <-forwarded; got != "ping "+payload {
+				t.Errorf("forwarded %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var localUsage = `Specify a local command to execute:
+  - search: Search the repositories and users in the local index`
+
+func executeLocal(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(localUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("local", "command", command)
+
+	switch command {
+	case "search":
+		return executeLocalSearch(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid local command: '%s'", command))
+	}
+}
+
+// ftsQuery turns a search term into a full text query matching the records
+// containing every word, or a word starting with it. The words are quoted,
+// the fts5 query syntax is not exposed.
+func ftsQuery(term string) string {
+	words := strings.Fields(term)
+
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"*`
+	}
+
+	return strings.Join(words, " ")
+}
+
+// scanRepositories reads the repositories selected from the repositories
+// table of the index, the columns are those of repositoryColumns.
+func scanRepositories(rows *sql.Rows) ([]repository, error) {
+	defer rows.Close()
+
+	repos := make([]repository, 0)
+
+	for rows.Next() {
+		r := repository{}
+
+		var topics, pushedAt string
+
+		err := rows.Scan(&r.FullName, &r.Description, &r.Language, &topics, &r.StargazersCount, &r.ForksCount,
+			&r.Fork, &r.Archived, &r.HTMLURL, &pushedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Topics = strings.Fields(topics)
+		r.PushedAt, _ = time.Parse(time.RFC3339, pushedAt)
+
+		repos = append(repos, r)
+	}
+
+	return repos, rows.Err()
+}
+
+// repositoryColumns are the columns scanRepositories reads.
+const repositoryColumns = "r.full_name, r.description, r.language, r.topics, r.stars, r.forks, r.fork, r.archived, r.html_url, r.pushed_at"
+
+func executeLocalSearch(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("local search", flag.ExitOnError)
+
+	kind := flagSet.String("kind", "repo", "what to search: repo or user")
+	limit := flagSet.Int("limit", 30, "maximum number of results to show")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Search term")
+
+	if len(args) == 0 {
+		return usageError("provide a search term: local search <term>")
+	}
+
+	term := strings.Join(args, " ")
+
+	loggerFrom(ctx).Debug("local search", "term", term, "kind", *kind)
+
+	if *kind != "repo" && *kind != "user" {
+		return usageErrorf("invalid kind '%s', expected repo or user", *kind)
+	}
+
+	db, err := openIndex(ctx)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return errors.New("failed to open the local index")
+	}
+	defer db.Close()
+
+	if *kind == "user" {
+		return searchIndexedUsers(ctx, db, term, *limit)
+	}
+
+	rows, err := db.Query(`SELECT `+repositoryColumns+` FROM repositories_fts
+		JOIN repositories r ON r.rowid = repositories_fts.rowid
+		WHERE repositories_fts MATCH ? ORDER BY bm25(repositories_fts) LIMIT ?`, ftsQuery(term), *limit)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return errors.New("failed to search the local index")
+	}
+
+	repos, err := scanRepositories(rows)
+	if err != nil {
+		return err
+	}
+
+	if len(repos) == 0 && !jsonOutput() {
+		fmt.Fprintln(os.Stderr, "No repositories in the local index match, they are added by the search and list commands")
+		return nil
+	}
+
+	return printRepositories(repos)
+}
+
+// searchIndexedUsers prints the users of the index matching term.
+func searchIndexedUsers(ctx context.Context, db *sql.DB, term string, limit int) error {
+	rows, err := db.Query(`SELECT u.login, u.name, u.bio, u.html_url FROM users_fts
+		JOIN users u ON u.rowid = users_fts.rowid
+		WHERE users_fts MATCH ? ORDER BY bm25(users_fts) LIMIT ?`, ftsQuery(term), limit)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return errors.New("failed to search the local index")
+	}
+	defer rows.Close()
+
+	users := make([]userProfile, 0)
+
+	for rows.Next() {
+		u := userProfile{}
+
+		err := rows.Scan(&u.Login, &u.Name, &u.Bio, &u.HTMLURL)
+		if err != nil {
+			return err
+		}
+
+		users = append(users, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(users)
+	}
+
+	if len(users) == 0 {
+		fmt.Fprintln(os.Stderr, "No users in the local index match, they are added by the search and list commands")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOGIN\tNAME\tBIO")
+
+	for _, u := range users {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", u.Login, u.Name, truncate(u.Bio, 60))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+type loggerKey struct{}
+
+// withLogger returns ctx carrying the logger of a command, the one
+// loggerFrom hands to everything the command calls.
+func withLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// loggerFrom returns the logger ctx carries. Without one, diagnostics still
+// go to stderr at the default level, so they never mix with results piped
+// on or a protocol spoken on stdout.
+func loggerFrom(ctx context.Context) *slog.Logger {
+	if log, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return log
+	}
+
+	return newLogger(os.Stderr, slog.LevelWarn, "text")
+}
+
+// setupLogger returns a logger following the -log-level, -log-format and
+// -log-file flags, writing to stderr. The log file, if any, is returned to
+// be closed on exit.
+func setupLogger() (*slog.Logger, *rotatingFile, error) {
+	level, err := parseLogLevel(*logLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if *debug {
+		level = slog.LevelDebug
+	}
+
+	format, err := parseLogFormat(*logFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := *logFile
+	if path == "" {
+		cfg, err := readConfig()
+		if err == nil {
+			path = cfg.LogFile
+		}
+	}
+
+	if path == "" {
+		return newLogger(os.Stderr, level, format), nil, nil
+	}
+
+	file, err := openLogFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return newLogger(io.MultiWriter(os.Stderr, file), level, format), file, nil
+}
+
+// newLogger returns a logger writing records of level and above to w as
+// key=value text or, with format json, as a json object per line for log
+// aggregators. Durations are seconds in json, secrets are masked.
+func newLogger(w io.Writer, level slog.Level, format string) *slog.Logger {
+	// Urls, headers and errors logged may carry tokens.
+	w = redactingWriter{w}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	if format == "json" {
+		options.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if a.Value.Kind() == slog.KindDuration {
+				a.Value = slog.Float64Value(a.Value.Duration().Seconds())
+			}
+
+			return a
+		}
+
+		return slog.New(slog.NewJSONHandler(w, options))
+	}
+
+	return slog.New(slog.NewTextHandler(w, options))
+}
+
+// parseLogFormat checks the -log-format flag, empty is text.
+func parseLogFormat(format string) (string, error) {
+	switch format {
+	case "", "text":
+		return "text", nil
+	case "json":
+		return "json", nil
+	default:
+		return "", usageErrorf("invalid log format '%s', expected text or json", format)
+	}
+}
+
+// parseLogLevel parses the -log-level flag, empty is warn.
+func parseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, usageErrorf("invalid log level '%s', expected debug, info, warn or error", level)
+	}
+}
+
+// loggingTransport logs every request to github with its status and how long
+// it took, at info level, and failed requests as warnings. Requests are sent
+// with the X-Request-Id of the invocation, or in serve with the id and
+// traceparent of the request of the client.
+type loggingTransport struct {
+	log  *slog.Logger
+	next http.RoundTripper
+}
+
+// logHTTP installs the logging transport on the default http client, in
+// front of the cassettes if any.
+func logHTTP(log *slog.Logger) {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	http.DefaultClient.Transport = &loggingTransport{log: log, next: next}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	log := t.log
+
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Request-Id", requestID)
+
+	if r := getActiveRequest(); r != nil {
+		log = log.With("serve_request_id", r.id)
+		req.Header.Set("X-Request-Id", r.id)
+
+		if req.Header.Get("traceparent") == "" && r.traceparent != "" {
+			req.Header.Set("traceparent", r.traceparent)
+		}
+	}
+
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	duration := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		log.Warn("request failed", "method", req.Method, "url", req.URL.String(), "duration", duration, "err", err)
+		return res, err
+	}
+
+	// Github support finds requests by their X-GitHub-Request-Id.
+	if id := res.Header.Get("X-GitHub-Request-Id"); id != "" {
+		log = log.With("github_request_id", id)
+	}
+
+	log.Info("request", "method", req.Method, "url", req.URL.String(), "status", res.StatusCode, "duration", duration)
+
+	return res, err
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// logFileMaxSize is the size at which the log file is rotated.
+	logFileMaxSize = 10 << 20
+
+	// logFileBackups is how many rotated log files are kept, as .1 the newest
+	// to .3 the oldest.
+	logFileBackups = 3
+)
+
+// rotatingFile appends to a log file and rotates it once it grows past
+// maxSize, so long running watches and servers do not fill the disk.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// openLogFile opens the log file at path for appending, creating it and its
+// directory when missing.
+func openLogFile(path string) (*rotatingFile, error) {
+	f := &rotatingFile{path: path, maxSize: logFileMaxSize, backups: logFileBackups}
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the log file: %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open the log file: %v", err)
+	}
+
+	f.file, f.size = file, info.Size()
+
+	return nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		err := f.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// rotate moves path to path.1, path.1 to path.2 and so on, dropping the
+// oldest, and starts a new file.
+func (f *rotatingFile) rotate() error {
+	f.file.Close()
+
+	os.Remove(fmt.Sprintf("%s.%d", f.path, f.backups))
+
+	for i := f.backups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+
+	os.Rename(f.path, f.path+".1")
+
+	return f.open()
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
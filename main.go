@@ -1,215 +1,751 @@
 // Available Commands:
 // - search-repos: Search for github repos
 // - search-users: Serach for users on github.
+// - repo: Inspect a github repository (view, readme, releases, branches, tags,
+//   contributors, languages, topics, license, cat, ls, download, compare,
+//   traffic, sbom, advisories, protection, checks, stargazers, collaborators,
+//   star, unstar, fork, clone, create, delete, events)
+// - release: Download release assets
+// - commit: Show commits of a repository
+// - advisories: Search security advisories
+// - user: Inspect github users (view, repos, followers, following, starred, sponsors,
+//   follow, unfollow, events)
+// - org: Inspect github organizations (repos, members, teams)
+// - issue: Manage issues (create, list)
+// - pr: Manage pull requests (list, checkout)
+// - gist: Manage gists (create)
+// - notifications: List unread notifications
+// - actions: Inspect github actions (runs, logs)
+// - label: Manage labels (list, create, delete, clone)
+// - milestone: Show milestones (list, view)
+// - project: Show projects (list, items)
+// - codespaces: Manage codespaces (list, stop, delete)
+// - packages: Show packages (list, versions)
+// - history: Show executed commands (list, search, run)
+// - bookmark: Keep repositories and users for later (add, list, remove)
+// - local: Search the local index of fetched repositories and users
+// - export: Export search results or the local index as csv or parquet
+// - serve: Serve the searches as a json http or grpc api, or as mcp tools on stdio
+// - listen: Receive github webhooks and print or forward them
+// - schedule: Run a command on a cron schedule
+// - api: Send any request to the github api
+// - audit: List the changes made on github (list)
+// - doctor: Check the setup: config, credentials, connectivity and rate limit
 //
 // Flags:
 // - Top level flags:
 //   - debug: Print the debug information as executing command
+//   - log-level: Level of the diagnostics on stderr, debug, info, warn or
+//     error, defaults to GCF_LOG_LEVEL or warn. Stdout only carries results
+//   - log-format: Format of the diagnostics, text or json with the level,
+//     time, command and the url, status and duration of requests, defaults
+//     to GCF_LOG_FORMAT or text
+//   - timings: Print a summary of the requests to github after the run: how
+//     many, cache hits, bytes transferred, time spent in requests and
+//     rendering and the rate limit left
+//   - log-file: Also append the diagnostics to a file, rotated at 10MB with
+//     three old files kept, for long running watches and servers
+//   - format: Output format of the results, table or json. With json
+//     errors are printed to stderr as {"error": {"code": ..., "message":
+//     ...}}, the code is the category of the exit code, e.g. rate_limited
+//   - quiet: Do not show spinners and progress bars on stderr
+//   - no-input: Fail on missing arguments instead of prompting for them
+//   - retries: How often to retry requests failing with a network error or
+//     a 502, 503 or 504 of github, 2 by default. Changes, like stars and
+//     created issues, are only retried when they could not be sent at all,
+//     github could make them twice otherwise
+//   - deadline: Total time the command may take, e.g. 30s, for scheduled
+//     and ci runs. Requests still in flight at the deadline are cancelled,
+//     however many pages or retries the command is at
+//   - dry-run: Print the request the command would send instead of sending
+//     it: the method, url with the query, the headers with the token masked
+//     and the body of changes. Commands sending a request per argument, like
+//     repo star, print every one
+//   - offline: Serve searches and the repository lists of users and orgs
+//     from the local index, every other request fails
+//   - rpc: Answer newline delimited json-rpc requests on stdin, for
+//     programs embedding the tool as a long lived subprocess. The methods
+//     are search_repos, search_users, get_repo and get_readme
+//
+// Pipelines:
+// - Commands taking a repository, user or organization accept a - in its
+//   place and then run for every one piped to stdin, e.g. from
+//   search-repos -q. Repositories are given as owner/name or as urls,
+//   users and organizations as login, @login or the url of the profile.
+// - A '|' argument chains commands within one invocation like a shell
+//   pipe, e.g. search-repos golang -q '|' repo view -
+//
+// Authentication:
+// - Commands that need authentication read a token from the GITHUB_TOKEN
+//   environment variable.
+//
+// Recording:
+// - GCF_RECORD=fixtures/ records the requests to github, and any download,
+//   to cassette files in the directory. Credentials are left out and secrets
+//   in urls, headers and bodies, like signed download urls, masked.
+// - GCF_REPLAY=fixtures/ serves the responses from the recorded cassettes
+//   instead of connecting, for deterministic tests and offline demos.
+//
+// Tracing:
+// - OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318 exports a span of the
+//   command and of every request to github to an OpenTelemetry collector,
+//   as json over http. OTEL_EXPORTER_OTLP_TRACES_ENDPOINT,
+//   OTEL_EXPORTER_OTLP_HEADERS and OTEL_SERVICE_NAME are honored, and
+//   TRACEPARENT joins the trace of a traced ci job.
+// - Every invocation has a request id, logged as request_id and sent to
+//   github as X-Request-Id. Serve takes the X-Request-Id and traceparent of
+//   its clients, or x-request-id and traceparent metadata over grpc, logs
+//   them and sends them on with the requests made for them.
+//
+// Library:
+// - The client of the github api is the pkg/github package, for other go
+//   programs to search repositories and users and send requests without
+//   the cli. Its transport can be replaced, e.g. for logging or tests
+//
+// Exit codes:
+// - Errors, usage, warnings, prompts and spinners are written to stderr,
+//   stdout only carries the results so piping never captures them
+// - 0: Success
+// - 1: Any other failure
+// - 2: Usage error, missing or invalid arguments and flags
+// - 3: Authentication error, no token, an invalid one or missing access
+// - 4: Rate limited by github
+// - 5: Network error, github could not be reached or -offline
+// - 6: Not found on github
+// - 7: Github answered with another error or an invalid response
+// - 8: The -deadline was exceeded
+// - 70: Crash, see the crash report
+//
+// Configuration:
+// - Settings are read from go-cli-flag/config.json in the user config
+//   directory, e.g. ~/.config/go-cli-flag/config.json:
+//   - git_protocol: Protocol of the git urls, https (default) or ssh
+//   - history: Record executed commands for the history command, true
+//     (default) or false
+//   - index: Keep fetched repositories and users in a local sqlite database
+//     in the data directory, true (default) or false
+//   - log_file: File the diagnostics are also appended to, like -log-file
+//   - audit: Append every change made on github, like stars, created
+//     issues and deleted repositories, to audit.jsonl in the data directory
+//     for the audit command, true or false (default)
+//   - secrets: Values masked in the logs, traces and crash reports, on top
+//     of the token, authorization headers, credentials in urls and the
+//     values of secret flags like --api-key, which always are
 //
 // Example:
 // - go run main.go -debug search-repos golang
 // - go run main.go -debug search-users gurleensethi
+// - go run main.go repo clone $(go run main.go search-repos cli --pick)
+// - go run main.go repo readme golang/go --render
+// - go run main.go search-repos golang -q | go run main.go repo view -
+// - go run main.go search-repos cli --interactive
+// - go run main.go search-repos --wizard
+// - go run main.go search-repos 'topic:cli created:>2024-01-01' --watch 5m --notify
+// - go run main.go search-repos 'topic:cli' --watch 10m --alert-url $SLACK_WEBHOOK_URL
+// - go run main.go search-repos 'language:go stars:>1000' --sort stars --web
+// - go run main.go search-repos golang --diff baseline --save baseline
+// - go run main.go search-repos 'topic:cli' --since last --since-field pushed -q
+// - go run main.go search-repos 'cli language:go' -q | go run main.go repo clone - --depth 1
+// - go run main.go -format json repo languages golang/go
+// - go run main.go release download cli/cli --tag v2.40.0 --pattern '*.tar.gz' --dir ./dl
+// - go run main.go commit view golang/go 6f8a3d1 --patch
+// - go run main.go advisories search --ecosystem go --severity high
+// - go run main.go user view gurleensethi
+// - go run main.go org repos golang --language go
+// - go run main.go issue create --repo owner/name --title 'Crash on start' --label bug
+// - go run main.go pr list --repo golang/go --label NeedsFix
+// - go run main.go gist create main.go go.mod --public --description 'Example'
+// - go run main.go notifications --participating --mark-read
+// - go run main.go actions runs golang/go --workflow ci.yml --status failure
+// - go run main.go actions logs golang/go --run 123456 --failed-only
+// - go run main.go label clone golang/go --repo owner/name
+// - go run main.go milestone list --repo golang/go
+// - go run main.go project items 1 --owner github
+// - go run main.go codespaces stop my-codespace-abc123
+// - go run main.go packages versions my-image --owner github --type container
+// - go run main.go history search search-repos
+// - go run main.go bookmark add golang/go --tag lang --note 'Read the compiler'
+// - go run main.go local search 'cli tool'
+// - go run main.go export 'language:go stars:>1000' --format parquet --output repos.parquet
+// - go run main.go serve --addr :8080 --grpc :9090 --cache-ttl 5m
+// - go run main.go serve --mcp
+// - echo '{"jsonrpc":"2.0","id":1,"method":"get_repo","params":{"full_name":"golang/go"}}' | go run main.go -rpc
+// - go run main.go listen --port 8080 --secret $WEBHOOK_SECRET --exec 'jq .event'
+// - go run main.go schedule '*/15 * * * *' -- search-repos topic:llm --diff llm --save llm --notify
+// - go run main.go api /repos/{owner}/{repo}/issues --method POST --field title=Bug --field labels[]=bug
+// - go run main.go audit list --since 24h
+// - go run main.go doctor
+// - go run main.go -dry-run issue create --repo owner/name --title Bug
 
 package main
 
 import (
-	"encoding/json"
+	"bufio"
+	"context"
 	"errors"
 	"flag"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
 )
 
 var (
-	debug = flag.Bool("debug", false, "log out all the debug information")
+	debug       = flag.Bool("debug", false, "log out all the debug information, same as -log-level debug")
+	logLevel    = flag.String("log-level", os.Getenv("GCF_LOG_LEVEL"), "level of the diagnostics written to stderr: debug, info, warn or error, defaults to GCF_LOG_LEVEL or warn")
+	logFormat   = flag.String("log-format", os.Getenv("GCF_LOG_FORMAT"), "format of the diagnostics: text, or json for log aggregators, defaults to GCF_LOG_FORMAT or text")
+	logFile     = flag.String("log-file", "", "also append the diagnostics to this file, rotated at 10MB, defaults to log_file of the config")
+	format      = flag.String("format", "table", "output format of the results: table or json")
+	quiet       = flag.Bool("quiet", false, "do not show spinners and progress bars")
+	noInput     = flag.Bool("no-input", false, "never prompt for missing arguments, fail instead")
+	offline     = flag.Bool("offline", false, "serve searches and lists from the local index without connecting to github")
+	rpc         = flag.Bool("rpc", false, "answer json-rpc requests read line by line from stdin, instead of running a command")
+	showTimings = flag.Bool("timings", false, "print how many requests the run sent, their size and duration and the rate limit left to stderr")
+	retries     = flag.Int("retries", 2, "how often to retry requests failing with a network error or an overloaded github, changes only when they were not sent")
+	deadline    = flag.Duration("deadline", 0, "total time the command may take, e.g. 30s, across all its requests, pages and retries")
+	dryRun      = flag.Bool("dry-run", false, "print the requests the command would send, with their headers and body, instead of sending them")
 
 	usage = `Specify a command to execute:
   - search-repos: Search for github repos
-  - search-users: Serach for users on github.`
+  - search-users: Serach for users on github.
+  - repo: Inspect a github repository
+  - release: Download release assets
+  - commit: Show commits of a repository
+  - advisories: Search security advisories
+  - user: Inspect github users
+  - org: Inspect github organizations (repos, members, teams)
+  - issue: Manage issues (create, list)
+  - pr: Manage pull requests (list, checkout)
+  - gist: Manage gists (create)
+  - notifications: List unread notifications
+  - actions: Inspect github actions (runs, logs)
+  - label: Manage labels (list, create, delete, clone)
+  - milestone: Show milestones (list, view)
+  - project: Show projects (list, items)
+  - codespaces: Manage codespaces (list, stop, delete)
+  - packages: Show packages (list, versions)
+  - history: Show executed commands (list, search, run)
+  - bookmark: Keep repositories and users for later (add, list, remove)
+  - local: Search the local index of fetched repositories and users
+  - export: Export search results or the local index as csv or parquet
+  - serve: Serve the searches as a json http or grpc api, or as mcp tools on stdio
+  - listen: Receive github webhooks and print or forward them
+  - schedule: Run a command on a cron schedule
+  - api: Send any request to the github api
+  - audit: List the changes made on github (list)
+  - doctor: Check the setup: config, credentials, connectivity and rate limit`
 )
 
 func main() {
+	defer recoverCrash()
+
 	flag.Parse()
 
-	if len(flag.Args()) < 1 {
-		fmt.Println(usage)
-		os.Exit(1)
+	logger, file, err := setupLogger()
+	if err != nil {
+		printError(err)
+		os.Exit(exitCode(err))
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	setupTracing(logger)
+
+	command := "rpc"
+
+	if !*rpc {
+		if len(flag.Args()) < 1 {
+			printError(usageError(usage))
+			os.Exit(exitCodes[categoryUsage])
+		}
+
+		command = flag.Args()[0]
 	}
 
-	command := flag.Args()[0]
+	logger = logger.With("command", command, "request_id", requestID)
+	ctx := withLogger(context.Background(), logger)
+
+	setupCassettes(logger)
+	logHTTP(logger)
+	auditHTTP(ctx, command)
+
+	if *showTimings {
+		timeHTTP()
+	}
+
+	if tracing != nil {
+		traceHTTP()
+	}
+
+	if *retries > 0 {
+		retryHTTP(logger)
+	}
+
+	if *deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = deadlineHTTP(ctx)
+		defer cancel()
+	}
+
+	if *dryRun {
+		dryRunHTTP()
+	}
+
+	span := tracing.startCommand(command, flag.Args())
+
+	if *rpc {
+		err := serveRPC(ctx)
+		if err != nil && *deadline > 0 && ctx.Err() != nil {
+			err = deadlineError()
+		}
+
+		tracing.end(span, err)
+		tracing.flush()
+		if err != nil {
+			printError(err)
+			os.Exit(exitCode(err))
+		}
+
+		return
+	}
+
+	if command != "history" {
+		recordHistory(ctx, sanitizeArgs(os.Args[1:]))
+	}
+
+	err = executePipeline(ctx, flag.Args())
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+
+	if err != nil && *deadline > 0 && ctx.Err() != nil {
+		err = deadlineError()
+	}
+
+	tracing.end(span, err)
+	tracing.flush()
+
+	if *showTimings {
+		printTimings()
+	}
 
-	err := executeCommand(command, flag.Args()[1:])
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		printError(err)
+		os.Exit(exitCode(err))
 	}
 }
 
-func executeCommand(command string, args []string) error {
-	printDebug(fmt.Sprintf("Command: %s", command))
-	printDebug(fmt.Sprintf("Args: %v", args))
+func executeCommand(ctx context.Context, command string, args []string) error {
+	loggerFrom(ctx).Debug("command", "name", command, "args", args)
 
 	switch command {
 	case "search-repos":
-		return executeSearchRepos(args)
+		return executeSearchRepos(ctx, args)
 	case "search-users":
-		return executeSearchUsers(args)
+		return executeSearchUsers(ctx, args)
+	case "repo":
+		return executeRepo(ctx, args)
+	case "release":
+		return executeRelease(ctx, args)
+	case "commit":
+		return executeCommit(ctx, args)
+	case "advisories":
+		return executeAdvisories(ctx, args)
+	case "user":
+		return executeUser(ctx, args)
+	case "org":
+		return executeOrg(ctx, args)
+	case "issue":
+		return executeIssue(ctx, args)
+	case "pr":
+		return executePR(ctx, args)
+	case "gist":
+		return executeGist(ctx, args)
+	case "notifications":
+		return executeNotifications(ctx, args)
+	case "actions":
+		return executeActions(ctx, args)
+	case "label":
+		return executeLabel(ctx, args)
+	case "milestone":
+		return executeMilestone(ctx, args)
+	case "project":
+		return executeProject(ctx, args)
+	case "codespaces":
+		return executeCodespaces(ctx, args)
+	case "packages":
+		return executePackages(ctx, args)
+	case "history":
+		return executeHistory(ctx, args)
+	case "bookmark":
+		return executeBookmark(ctx, args)
+	case "local":
+		return executeLocal(ctx, args)
+	case "export":
+		return executeExport(ctx, args)
+	case "serve":
+		return executeServe(ctx, args)
+	case "listen":
+		return executeListen(ctx, args)
+	case "schedule":
+		return executeSchedule(ctx, args)
+	case "api":
+		return executeAPI(ctx, args)
+	case "audit":
+		return executeAudit(ctx, args)
+	case "doctor":
+		return executeDoctor(ctx, args)
 	default:
-		return fmt.Errorf("invalid command: '%s'", command)
+		return usageError(fmt.Sprintf("invalid command: '%s'", command))
 	}
 }
 
-func executeSearchRepos(args []string) error {
-	if len(args) == 0 {
-		return errors.New("provide a search term for searching repos: search-repos <search_term>")
+func executeSearchRepos(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("search-repos", flag.ExitOnError)
+
+	namesOnly := flagSet.Bool("q", false, "only print the repository names, one per line")
+	interactive := flagSet.Bool("interactive", false, "browse the results in a full screen view")
+	pick := flagSet.Bool("pick", false, "fuzzy pick results and only print the picked ones")
+	wizard := flagSet.Bool("wizard", false, "build the search query step by step with prompts")
+	sort := flagSet.String("sort", "", "sort results by stars, forks or updated, defaults to best match")
+	web := flagSet.Bool("web", false, "open the search in the browser, with --pick the picked repositories")
+	watch := flagSet.Duration("watch", 0, "re-run the search on an interval, e.g. 30s, and print what changed")
+	notify := flagSet.Bool("notify", false, "with --watch or --diff, show a desktop notification when new results appear")
+	alertURL := flagSet.String("alert-url", os.Getenv("GCF_ALERT_URL"), "with --watch or --diff, post new results to a slack, discord or other incoming webhook, defaults to GCF_ALERT_URL")
+	alertText := flagSet.String("alert-template", "", "go template of the alert message, executed with .Term, .Count and .Repositories")
+	alertFile := flagSet.String("alert-template-file", "", "read the alert template from a file")
+	metricsAddr := flagSet.String("metrics-addr", "", "with --watch, serve prometheus metrics on /metrics of an address, e.g. :9100")
+	save := flagSet.String("save", "", "save the results as a named snapshot to diff later runs against")
+	diff := flagSet.String("diff", "", "print the repositories added, removed or changed since a saved snapshot")
+	since := addSinceFlags(flagSet, "created", "pushed")
+
+	args = parseArgs(flagSet, args)
+
+	var searchTerm string
+
+	if *wizard {
+		keywords := strings.Join(args, " ")
+
+		var err error
+		searchTerm, *sort, err = searchWizard(keywords)
+		if err != nil {
+			return err
+		}
+
+		// Show the query so it can be reused, or tweaked, without the wizard.
+		command := fmt.Sprintf("search-repos '%s'", searchTerm)
+		if *sort != "" {
+			command += " --sort " + *sort
+		}
+		fmt.Fprintf(os.Stderr, "Query: %s\nRunning: %s\n", searchTerm, command)
+	} else {
+		args = promptMissing(args, "Search term")
+
+		if len(args) == 0 {
+			return usageError("provide a search term for searching repos: search-repos <search_term>")
+		}
+
+		searchTerm = args[0]
 	}
 
-	searchTerm := args[0]
+	loggerFrom(ctx).Debug("search-repos", "search_term", searchTerm, "sort", *sort)
+
+	// The run is recorded with the time it started so results created while
+	// it runs are found by the next run.
+	runKey := "search-repos " + searchTerm + " " + since.field
+	started := time.Now()
+
+	searchTerm, err := since.apply(ctx, runKey, searchTerm)
+	if err != nil {
+		return err
+	}
+
+	if *web && !*pick {
+		return openBrowser(ctx, searchWebURL(searchTerm, "repositories", *sort))
+	}
+
+	text, err := alertTemplate(*alertText, *alertFile)
+	if err != nil {
+		return err
+	}
+
+	alert, err := newAlerter(*alertURL, text)
+	if err != nil {
+		return err
+	}
+
+	if *watch > 0 {
+		if *metricsAddr != "" {
+			instrumentHTTP()
+			serveMetrics(ctx, *metricsAddr)
+		}
+
+		return watchSearchRepos(ctx, searchTerm, *sort, *watch, *notify, alert)
+	}
+
+	if *save != "" || *diff != "" {
+		return diffSearchRepos(ctx, searchTerm, *sort, *save, *diff, *notify, alert)
+	}
 
-	printDebug(fmt.Sprintf("[search-repos] Search Term: %s", searchTerm))
+	if *interactive {
+		repos, err := searchRepositories(ctx, searchTerm, *sort)
+		if err != nil {
+			return err
+		}
 
-	repos, err := findRepos(searchTerm)
+		return browseRepositories(ctx, fmt.Sprintf("search-repos %s", searchTerm), repos)
+	}
+
+	repos, err := findRepos(ctx, searchTerm, *sort)
 	if err != nil {
 		return err
 	}
 
+	recordRun(ctx, runKey, started)
+
+	if *pick {
+		repos, err = pickItems(ctx, repos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *web {
+		urls := make([]string, len(repos))
+		for i, r := range repos {
+			urls[i] = "https://github.com/" + r
+		}
+
+		return openBrowserAll(ctx, urls)
+	}
+
+	if *namesOnly || *pick {
+		for _, r := range repos {
+			fmt.Println(r)
+		}
+		return nil
+	}
+
 	fmt.Println(strings.Join(repos, ", "))
 
 	return nil
 }
 
-func executeSearchUsers(args []string) error {
+func executeSearchUsers(ctx context.Context, args []string) error {
 	flagSet := flag.NewFlagSet("search-repos", flag.ExitOnError)
 
 	sort := flagSet.String("sort", "", "sort results by")
+	pick := flagSet.Bool("pick", false, "fuzzy pick users and only print the picked ones")
+	web := flagSet.Bool("web", false, "open the search in the browser, with --pick the picked profiles")
+	since := addSinceFlags(flagSet, "created")
 
 	flagSet.Parse(args)
 
-	printDebug(fmt.Sprintf("[search-repos] Args: %s", flagSet.Args()))
+	loggerFrom(ctx).Debug("search-repos", "args", flagSet.Args())
+
+	args = promptMissing(flagSet.Args(), "Search term")
 
-	if len(flagSet.Args()) == 0 {
-		return errors.New("provide a search term for searching repos: search-repos <search_term>")
+	if len(args) == 0 {
+		return usageError("provide a search term for searching repos: search-repos <search_term>")
 	}
 
-	searchTerm := flagSet.Args()[0]
+	searchTerm := args[0]
+
+	loggerFrom(ctx).Debug("search-repos", "search_term", searchTerm)
 
-	printDebug(fmt.Sprintf("[search-repos] Search Term: %s", searchTerm))
+	runKey := "search-users " + searchTerm
+	started := time.Now()
 
-	users, err := findUsers(searchTerm, *sort)
+	searchTerm, err := since.apply(ctx, runKey, searchTerm)
 	if err != nil {
 		return err
 	}
 
+	if *web && !*pick {
+		return openBrowser(ctx, searchWebURL(searchTerm, "users", *sort))
+	}
+
+	users, err := findUsers(ctx, searchTerm, *sort)
+	if err != nil {
+		return err
+	}
+
+	recordRun(ctx, runKey, started)
+
+	if *pick {
+		users, err = pickItems(ctx, users)
+		if err != nil {
+			return err
+		}
+
+		if *web {
+			urls := make([]string, len(users))
+			for i, u := range users {
+				urls[i] = "https://github.com/" + u
+			}
+
+			return openBrowserAll(ctx, urls)
+		}
+
+		fmt.Println(strings.Join(users, "\n"))
+		return nil
+	}
+
 	fmt.Println(strings.Join(users, ", "))
 
 	return nil
 }
 
-func findRepos(term string) ([]string, error) {
-	type repo struct {
-		FullName string `json:"full_Name"`
-	}
+func findRepos(ctx context.Context, term, sort string) ([]string, error) {
+	if *offline {
+		repos, err := offlineRepositories(ctx, term, sort, 30)
+		if err != nil {
+			return nil, err
+		}
 
-	type searchResult struct {
-		Items []repo `json:"items"`
-	}
+		names := make([]string, len(repos))
+		for i, r := range repos {
+			names[i] = r.FullName
+		}
 
-	// Prepare github repository search url.
-	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/search/repositories", nil)
-	if err != nil {
-		printDebug(fmt.Sprintf("%v", err))
-		return nil, errors.New("failed to connect to github")
+		return names, nil
 	}
 
-	query := req.URL.Query()
-	query.Set("q", term)
-	req.URL.RawQuery = query.Encode()
-
-	// Make http request.
-	res, err := http.DefaultClient.Do(req)
+	result, err := githubClient().SearchRepositories(ctx, term, &github.SearchOptions{Sort: sort})
 	if err != nil {
-		printDebug(fmt.Sprintf("%v", err))
-		return nil, errors.New("failed to connect to github")
+		return nil, requestError(ctx, err)
 	}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, errors.New("failed to connect to github")
-	}
+	items := result.Items
+	skippedResults(ctx, result.Skipped)
 
-	// Parse the json response.
-	results := searchResult{}
-
-	err = json.NewDecoder(res.Body).Decode(&results)
-	if err != nil {
-		printDebug(fmt.Sprintf("%v", err))
-		return nil, errors.New("failed to connect to github")
-	}
+	indexRepositories(ctx, items)
 
 	// Extract out the repo names.
 	repos := make([]string, 0)
 
-	for _, r := range results.Items {
+	for _, r := range items {
 		repos = append(repos, r.FullName)
 	}
 
 	return repos, nil
 }
 
-func findUsers(term, sort string) ([]string, error) {
-	type user struct {
-		Login string `json:"login"`
-	}
-
-	type searchResult struct {
-		Items []user `json:"items"`
+// searchRepositories is like findRepos but returns the full repositories
+// instead of only the names.
+func searchRepositories(ctx context.Context, term, sort string) ([]repository, error) {
+	if *offline {
+		return offlineRepositories(ctx, term, sort, 100)
 	}
 
-	// Prepare github repository search url.
-	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/search/users", nil)
+	result, err := githubClient().SearchRepositories(ctx, term, &github.SearchOptions{Sort: sort, PerPage: 100})
 	if err != nil {
-		printDebug(fmt.Sprintf("%v", err))
-		return nil, errors.New("failed to connect to github")
+		return nil, requestError(ctx, err)
 	}
 
-	query := req.URL.Query()
-	query.Set("q", term)
-	query.Set("sort", sort)
-	req.URL.RawQuery = query.Encode()
+	repos := result.Items
+	skippedResults(ctx, result.Skipped)
 
-	// Make http request.
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		printDebug(fmt.Sprintf("%v", err))
-		return nil, errors.New("failed to connect to github")
-	}
+	indexRepositories(ctx, repos)
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, errors.New("failed to connect to github")
-	}
+	return repos, nil
+}
 
-	// Parse the json response.
-	results := searchResult{}
+func findUsers(ctx context.Context, term, sort string) ([]string, error) {
+	if *offline {
+		return offlineUsers(ctx, term)
+	}
 
-	err = json.NewDecoder(res.Body).Decode(&results)
+	result, err := githubClient().SearchUsers(ctx, term, &github.SearchOptions{Sort: sort})
 	if err != nil {
-		printDebug(fmt.Sprintf("%v", err))
-		return nil, errors.New("failed to connect to github")
+		return nil, requestError(ctx, err)
 	}
 
+	items := result.Items
+	skippedResults(ctx, result.Skipped)
+
+	indexUserSummaries(ctx, items)
+
 	// Extract out the repo names.
 	repos := make([]string, 0)
 
-	for _, r := range results.Items {
+	for _, r := range items {
 		repos = append(repos, r.Login)
 	}
 
 	return repos, nil
 }
 
-func printDebug(msg string) {
-	if *debug {
-		fmt.Printf("[DEBUG]: %s\n", msg)
+// parseArgs parses flags that may appear before, after or in between
+// positional arguments and returns the positional arguments. Everything after
+// a "--" terminator is returned as is.
+func parseArgs(flagSet *flag.FlagSet, args []string) []string {
+	positional := make([]string, 0)
+
+	for {
+		flagSet.Parse(args)
+
+		consumed := len(args) - len(flagSet.Args())
+		if consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, flagSet.Args()...)
+		}
+
+		args = flagSet.Args()
+		if len(args) == 0 {
+			return positional
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// stringList is a flag that can be repeated or given a comma separated list
+// of values, e.g. --label bug --label docs or --label bug,docs.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*s = append(*s, v)
+		}
+	}
+
+	return nil
+}
+
+// readLines reads the non empty lines of the file at path, "-" reads stdin.
+// Lines starting with a "#" are comments and skipped.
+func readLines(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		r = file
 	}
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	ansiReset     = "\033[0m"
+	ansiBold      = "\033[1m"
+	ansiDim       = "\033[2m"
+	ansiUnderline = "\033[4m"
+	ansiReverse   = "\033[7m"
+	ansiRed       = "\033[31m"
+	ansiGreen     = "\033[32m"
+	ansiYellow    = "\033[33m"
+	ansiCyan      = "\033[36m"
+)
+
+var (
+	markdownBold   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownCode   = regexp.MustCompile("`([^`]+)`")
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	markdownBullet = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+)
+
+// renderMarkdown does a light weight rendering of markdown for the terminal
+// using ansi escape codes. It only handles the common cases found in readmes:
+// headings, bullet lists, code blocks, bold text, inline code and links.
+func renderMarkdown(md string) string {
+	lines := strings.Split(md, "\n")
+	rendered := make([]string, 0, len(lines))
+	inCode := false
+
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+
+		if inCode {
+			rendered = append(rendered, ansiDim+"    "+line+ansiReset)
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			heading := strings.TrimSpace(strings.TrimLeft(line, "#"))
+			rendered = append(rendered, ansiBold+ansiUnderline+heading+ansiReset)
+			continue
+		}
+
+		// Links go first so the brackets of the inserted escape codes are not
+		// mistaken for markdown.
+		line = markdownLink.ReplaceAllString(line, ansiUnderline+"$1"+ansiReset+" ($2)")
+		line = markdownBullet.ReplaceAllString(line, "$1• ")
+		line = markdownBold.ReplaceAllString(line, ansiBold+"$1"+ansiReset)
+		line = markdownCode.ReplaceAllString(line, ansiCyan+"$1"+ansiReset)
+
+		rendered = append(rendered, line)
+	}
+
+	return strings.Join(rendered, "\n")
+}
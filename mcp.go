@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// mcpProtocolVersions are the Model Context Protocol revisions serve --mcp
+// speaks, the newest first.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// mcpTool is a tool as listed by tools/list, the input schema is a json
+// schema of the arguments.
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+func objectSchema(required []string, properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+var mcpTools = []mcpTool{
+	{
+		Name:        "search_repos",
+		Description: "Search github repositories with the github search syntax, e.g. 'language:go stars:>1000 cli'.",
+		InputSchema: objectSchema([]string{"query"}, map[string]interface{}{
+			"query": map[string]interface{}{"type": "string", "description": "github repository search query"},
+			"sort":  map[string]interface{}{"type": "string", "enum": []string{"stars", "forks", "updated"}, "description": "sort order, defaults to best match"},
+			"limit": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 100, "description": "maximum number of repositories, defaults to 30"},
+		}),
+	},
+	{
+		Name:        "search_users",
+		Description: "Search github users with the github search syntax, e.g. 'location:berlin followers:>100'.",
+		InputSchema: objectSchema([]string{"query"}, map[string]interface{}{
+			"query": map[string]interface{}{"type": "string", "description": "github user search query"},
+			"sort":  map[string]interface{}{"type": "string", "enum": []string{"followers", "repositories", "joined"}, "description": "sort order, defaults to best match"},
+		}),
+	},
+	{
+		Name:        "get_repo",
+		Description: "Get the details of a github repository: description, language, topics, stars, forks and more.",
+		InputSchema: objectSchema([]string{"full_name"}, map[string]interface{}{
+			"full_name": map[string]interface{}{"type": "string", "description": "repository as owner/name"},
+		}),
+	},
+	{
+		Name:        "get_readme",
+		Description: "Get the readme of a github repository as markdown.",
+		InputSchema: objectSchema([]string{"full_name"}, map[string]interface{}{
+			"full_name": map[string]interface{}{"type": "string", "description": "repository as owner/name"},
+		}),
+	},
+}
+
+// serveMCP serves the search and repository tools over the Model Context
+// Protocol on stdin and stdout, for assistants running the binary as a
+// subprocess. Requests to github use the token of the environment, and
+// -offline answers from the local index as usual.
+func serveMCP(ctx context.Context) error {
+	// Stdout carries the protocol.
+	*quiet = true
+
+	fmt.Fprintln(os.Stderr, "Serving MCP on stdio")
+
+	return serveJSONRPC(ctx, os.Stdin, os.Stdout, handleMCP)
+}
+
+func handleMCP(ctx context.Context, method string, params json.RawMessage) (interface{}, *rpcError) {
+	loggerFrom(ctx).Debug("serve mcp", "method", method)
+
+	switch method {
+	case "initialize":
+		return mcpInitialize(params)
+	case "ping":
+		return nil, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": mcpTools}, nil
+	case "tools/call":
+		return mcpCallTool(ctx, params)
+	default:
+		// Notifications like notifications/initialized need no answer.
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method '%s' not found", method)}
+	}
+}
+
+func mcpInitialize(params json.RawMessage) (interface{}, *rpcError) {
+	request := struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}{}
+
+	if err := decodeParams(params, &request); err != nil {
+		return nil, err
+	}
+
+	// Answer with the version of the client when supported, or the latest.
+	version := mcpProtocolVersions[0]
+	if containsString(mcpProtocolVersions, request.ProtocolVersion) {
+		version = request.ProtocolVersion
+	}
+
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]string{
+			"name":    "go-cli-flag",
+			"version": "dev",
+		},
+	}, nil
+}
+
+// mcpCallTool runs a tool. Failed github requests are tool results with
+// isError set, so the assistant sees them, instead of protocol errors.
+func mcpCallTool(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	call := struct {
+		Name      string        `json:"name"`
+		Arguments toolArguments `json:"arguments"`
+	}{}
+
+	if err := decodeParams(params, &call); err != nil {
+		return nil, err
+	}
+
+	result, found, err := callTool(ctx, call.Name, call.Arguments)
+	if !found {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("unknown tool '%s'", call.Name)}
+	}
+
+	if err != nil {
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+
+	// Readmes are markdown already, everything else is sent as json.
+	text, ok := result.(string)
+	if !ok {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, &rpcError{Code: rpcInternalError, Message: err.Error()}
+		}
+		text = string(data)
+	}
+
+	return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}, nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricInfo describes a metric for the HELP and TYPE lines of /metrics.
+type metricInfo struct {
+	kind string
+	help string
+}
+
+var metricInfos = map[string]metricInfo{
+	"gcf_github_requests_total":           {"counter", "Requests sent to github by method and status code."},
+	"gcf_github_request_duration_seconds": {"histogram", "Duration of the requests sent to github."},
+	"gcf_github_rate_limit_remaining":     {"gauge", "Requests left in the current github rate limit window by resource."},
+	"gcf_github_rate_limit_limit":         {"gauge", "Requests allowed per github rate limit window by resource."},
+	"gcf_github_rate_limit_reset":         {"gauge", "Unix time the github rate limit window resets by resource."},
+	"gcf_requests_total":                  {"counter", "Requests served by serve by api, handler and status code."},
+	"gcf_request_duration_seconds":        {"histogram", "Duration of the requests served by serve."},
+	"gcf_cache_hits_total":                {"counter", "Searches answered from the cache of serve."},
+	"gcf_cache_misses_total":              {"counter", "Searches not found in the cache of serve."},
+	"gcf_cache_hit_ratio":                 {"gauge", "Share of the searches answered from the cache of serve."},
+	"gcf_watch_runs_total":                {"counter", "Runs of a watched search by result."},
+	"gcf_watch_changes_total":             {"counter", "Changes found by a watched search by kind."},
+}
+
+// durationBuckets are the upper bounds of the duration histograms.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// metricsRegistry keeps the metrics of the process in the prometheus text
+// format. Series are keyed by name and rendered labels.
+type metricsRegistry struct {
+	mu         sync.Mutex
+	values     map[string]map[string]float64
+	histograms map[string]map[string]*histogram
+}
+
+var metrics = &metricsRegistry{
+	values:     make(map[string]map[string]float64),
+	histograms: make(map[string]map[string]*histogram),
+}
+
+// renderLabels formats name, value pairs as a prometheus label set.
+func renderLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", labels[i], strconv.Quote(labels[i+1])))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (m *metricsRegistry) series(name string) map[string]float64 {
+	if m.values[name] == nil {
+		m.values[name] = make(map[string]float64)
+	}
+
+	return m.values[name]
+}
+
+// add adds delta to a counter, labels are name, value pairs.
+func (m *metricsRegistry) add(name string, delta float64, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.series(name)[renderLabels(labels)] += delta
+}
+
+func (m *metricsRegistry) inc(name string, labels ...string) {
+	m.add(name, 1, labels...)
+}
+
+// set sets a gauge, labels are name, value pairs.
+func (m *metricsRegistry) set(name string, value float64, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.series(name)[renderLabels(labels)] = value
+}
+
+func (m *metricsRegistry) get(name string, labels ...string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.values[name][renderLabels(labels)]
+}
+
+// observe records a duration in a histogram.
+func (m *metricsRegistry) observe(name string, d time.Duration, labels ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.histograms[name] == nil {
+		m.histograms[name] = make(map[string]*histogram)
+	}
+
+	key := renderLabels(labels)
+
+	h := m.histograms[name][key]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(durationBuckets))}
+		m.histograms[name][key] = h
+	}
+
+	seconds := d.Seconds()
+
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += seconds
+}
+
+// write renders every metric in the prometheus text format.
+func (m *metricsRegistry) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.values)+len(m.histograms))
+	for name := range m.values {
+		names = append(names, name)
+	}
+	for name := range m.histograms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		info := metricInfos[name]
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, info.help, name, info.kind)
+
+		for _, key := range sortedKeys(m.values[name]) {
+			fmt.Fprintf(w, "%s%s %s\n", name, key, strconv.FormatFloat(m.values[name][key], 'g', -1, 64))
+		}
+
+		for _, key := range sortedKeys(m.histograms[name]) {
+			h := m.histograms[name][key]
+
+			// The le label goes with the other labels of the series.
+			labels := strings.TrimSuffix(strings.TrimPrefix(key, "{"), "}")
+			if labels != "" {
+				labels += ","
+			}
+
+			for i, bound := range durationBuckets {
+				fmt.Fprintf(w, "%s_bucket{%sle=\"%s\"} %d\n", name, labels, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+			}
+
+			fmt.Fprintf(w, "%s_bucket{%sle=\"+Inf\"} %d\n", name, labels, h.count)
+			fmt.Fprintf(w, "%s_sum%s %s\n", name, key, strconv.FormatFloat(h.sum, 'g', -1, 64))
+			fmt.Fprintf(w, "%s_count%s %d\n", name, key, h.count)
+		}
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// recordCacheLookup counts a lookup in the cache of serve and updates the
+// hit ratio.
+func recordCacheLookup(hit bool) {
+	if hit {
+		metrics.inc("gcf_cache_hits_total")
+	} else {
+		metrics.inc("gcf_cache_misses_total")
+	}
+
+	hits := metrics.get("gcf_cache_hits_total")
+	misses := metrics.get("gcf_cache_misses_total")
+
+	metrics.set("gcf_cache_hit_ratio", hits/(hits+misses))
+}
+
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	metrics.write(w)
+}
+
+// metricsTransport counts the requests sent to github, times them and keeps
+// the rate limit reported in the response headers.
+type metricsTransport struct {
+	next http.RoundTripper
+}
+
+// instrumentHTTP wraps the transport of the default http client, which may
+// already be a cassette transport, to collect the github metrics.
+func instrumentHTTP() {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	http.DefaultClient.Transport = &metricsTransport{next: next}
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	metrics.observe("gcf_github_request_duration_seconds", time.Since(start))
+
+	if err != nil {
+		metrics.inc("gcf_github_requests_total", "method", req.Method, "code", "error")
+		return res, err
+	}
+
+	metrics.inc("gcf_github_requests_total", "method", req.Method, "code", strconv.Itoa(res.StatusCode))
+
+	resource := res.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+
+	for header, name := range map[string]string{
+		"X-RateLimit-Remaining": "gcf_github_rate_limit_remaining",
+		"X-RateLimit-Limit":     "gcf_github_rate_limit_limit",
+		"X-RateLimit-Reset":     "gcf_github_rate_limit_reset",
+	} {
+		if value, err := strconv.ParseFloat(res.Header.Get(header), 64); err == nil {
+			metrics.set(name, value, "resource", resource)
+		}
+	}
+
+	return res, nil
+}
+
+// serveMetrics serves /metrics on addr in the background, for modes that
+// do not serve http otherwise, like --watch.
+func serveMetrics(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", metricsHandler)
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil {
+			loggerFrom(ctx).Error("serving metrics failed", "addr", addr, "err", err)
+		}
+	}()
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var milestoneUsage = `Specify a milestone command to execute:
+  - list: List the milestones of a repository
+  - view: Show the progress of a milestone`
+
+func executeMilestone(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(milestoneUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("milestone", "command", command)
+
+	switch command {
+	case "list":
+		return executeMilestoneList(ctx, args[1:])
+	case "view":
+		return executeMilestoneView(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid milestone command: '%s'", command))
+	}
+}
+
+type milestone = github.Milestone
+
+// milestoneDue returns the due date of a milestone, marking open milestones
+// that are past it.
+func milestoneDue(m milestone) string {
+	if m.DueOn == nil {
+		return "-"
+	}
+
+	due := m.DueOn.Format("2006-01-02")
+
+	if m.State == "open" && m.DueOn.Before(time.Now()) {
+		return due + " (overdue)"
+	}
+
+	return due
+}
+
+// completionBar renders a share between 0 and 1 as a bar of width cells
+// followed by the percentage, e.g. "[=====     ]  50%".
+func completionBar(share float64, width int) string {
+	filled := int(share * float64(width))
+
+	return fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat("=", filled), strings.Repeat(" ", width-filled), share*100)
+}
+
+func executeMilestoneList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("milestone list", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to list the milestones of, owner/name")
+	state := flagSet.String("state", "open", "state of the milestones: open, closed or all")
+
+	parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil {
+		return usageError("provide a repository: milestone list --repo <owner/name>")
+	}
+
+	loggerFrom(ctx).Debug("milestone list", "repo", owner+"/"+name, "state", *state)
+
+	milestones, err := collectPages(ctx, githubClient().ListMilestones(ctx, owner, name, *state), 0, nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(milestones)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NUMBER\tTITLE\tSTATE\tOPEN\tCLOSED\tDUE\tPROGRESS")
+
+	for _, m := range milestones {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\t%s\n", m.Number, m.Title, m.State, m.OpenIssues, m.ClosedIssues, milestoneDue(m), completionBar(m.Completion(), 20))
+	}
+
+	return w.Flush()
+}
+
+func executeMilestoneView(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("milestone view", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository of the milestone, owner/name")
+	web := flagSet.Bool("web", false, "open the milestone in the browser")
+
+	args = parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil || len(args) == 0 {
+		return usageError("provide a repository and milestone: milestone view <number> --repo <owner/name>")
+	}
+
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return usageErrorf("invalid milestone number '%s'", args[0])
+	}
+
+	loggerFrom(ctx).Debug("milestone view", "repo", owner+"/"+name, "number", number)
+
+	if *web {
+		return openBrowser(ctx, fmt.Sprintf("https://github.com/%s/%s/milestone/%d", owner, name, number))
+	}
+
+	m, err := githubClient().GetMilestone(ctx, owner, name, number)
+	err = requestError(ctx, err)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("milestone %d not found in %s/%s", number, owner, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(m)
+	}
+
+	fmt.Println(bold(m.Title))
+	if m.Description != "" {
+		fmt.Println(m.Description)
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "State:\t%s\n", m.State)
+	fmt.Fprintf(w, "Due:\t%s\n", milestoneDue(*m))
+	fmt.Fprintf(w, "Issues:\t%d open, %d closed\n", m.OpenIssues, m.ClosedIssues)
+	fmt.Fprintf(w, "Progress:\t%s\n", completionBar(m.Completion(), 30))
+	fmt.Fprintf(w, "URL:\t%s\n", m.HTMLURL)
+
+	return w.Flush()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+type notification = github.Notification
+
+func executeNotifications(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("notifications", flag.ExitOnError)
+
+	participating := flagSet.Bool("participating", false, "only show notifications of threads you participate in or are mentioned in")
+	markRead := flagSet.Bool("mark-read", false, "mark the listed notifications as read")
+	page := addPageFlags(flagSet)
+
+	parseArgs(flagSet, args)
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("notifications", "participating", *participating, "mark_read", *markRead)
+
+	client := githubClient()
+
+	// Only unread notifications are returned by default.
+	notifications, err := collectPages(ctx, client.ListNotifications(ctx, *participating), page.max(), nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		err = printJSON(notifications)
+	} else {
+		err = printNotifications(notifications)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !*markRead {
+		return nil
+	}
+
+	// Threads are marked one by one so notifications that arrived after the
+	// listing stay unread.
+	for _, n := range notifications {
+		err := client.MarkThreadRead(ctx, n.ID)
+		if err != nil {
+			return requestError(ctx, err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Marked %d notifications as read\n", len(notifications))
+
+	return nil
+}
+
+func printNotifications(notifications []notification) error {
+	if len(notifications) == 0 {
+		fmt.Fprintln(os.Stderr, "No unread notifications")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tTYPE\tSUBJECT\tREASON\tAGE")
+
+	for _, n := range notifications {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s ago\n", n.Repository.FullName, n.Subject.Type, truncate(n.Subject.Title, 60), n.Reason, formatAge(n.UpdatedAt))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// sendNotification shows a desktop notification using the notification
+// tool of the platform.
+func sendNotification(ctx context.Context, title, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		// A balloon tip works without extra modules, unlike toast notifications.
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms; `+
+			`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+			`$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; `+
+			`$n.ShowBalloonTip(10000, '%s', '%s', 'Info'); Start-Sleep -Seconds 10; $n.Dispose()`,
+			strings.ReplaceAll(title, "'", "''"), strings.ReplaceAll(message, "'", "''"))
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+	default:
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return errors.New("no notification tool found, install notify-send (libnotify)")
+		}
+		cmd = exec.Command("notify-send", title, message)
+	}
+
+	loggerFrom(ctx).Debug("notify", "title", title, "message", message)
+
+	// The windows script waits for the balloon to go away, do not block on it.
+	if runtime.GOOS == "windows" {
+		return cmd.Start()
+	}
+
+	return cmd.Run()
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errOffline is returned for requests to github in offline mode.
+var errOffline = errors.New("not available offline, only searches and the repository lists of users and orgs are served from the local index")
+
+// offlineRepositories searches the repositories of the local index like the
+// github search would in offline mode. The words of the term are matched
+// against the full text index, of the search qualifiers only language, user
+// and org are supported, the others are ignored.
+func offlineRepositories(ctx context.Context, term, sort string, limit int) ([]repository, error) {
+	words := make([]string, 0)
+	where := make([]string, 0)
+	params := make([]interface{}, 0)
+
+	for _, w := range strings.Fields(term) {
+		qualifier, value, ok := strings.Cut(w, ":")
+		if !ok {
+			words = append(words, w)
+			continue
+		}
+
+		switch qualifier {
+		case "language":
+			where = append(where, "r.language = ? COLLATE NOCASE")
+			params = append(params, value)
+		case "user", "org":
+			where = append(where, "r.full_name LIKE ?")
+			params = append(params, value+"/%")
+		default:
+			loggerFrom(ctx).Debug("offline: ignoring qualifier", "qualifier", w)
+		}
+	}
+
+	from := "repositories r"
+	order := "r.full_name"
+
+	if len(words) > 0 {
+		from = "repositories_fts JOIN repositories r ON r.rowid = repositories_fts.rowid"
+		order = "bm25(repositories_fts)"
+		where = append(where, "repositories_fts MATCH ?")
+		params = append(params, ftsQuery(strings.Join(words, " ")))
+	}
+
+	switch sort {
+	case "stars":
+		order = "r.stars DESC"
+	case "forks":
+		order = "r.forks DESC"
+	case "updated":
+		order = "r.pushed_at DESC"
+	}
+
+	repos, err := queryIndexedRepositories(ctx, from, where, order, limit, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories matching '%s' in the local index, run the search once without -offline", term)
+	}
+
+	return repos, nil
+}
+
+// offlineOwnerRepositories returns the repositories of owner in the local
+// index which keep returns true for, sorted like the users and orgs lists.
+func offlineOwnerRepositories(ctx context.Context, owner, sort string, limit int, keep func(repository) bool) ([]repository, error) {
+	order := "r.pushed_at DESC"
+	if sort == "full_name" {
+		order = "r.full_name"
+	}
+
+	repos, err := queryIndexedRepositories(ctx, "repositories r", []string{"r.full_name LIKE ?"}, order, 0, []interface{}{owner + "/%"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories of %s in the local index, list them once without -offline", owner)
+	}
+
+	kept := make([]repository, 0)
+
+	for _, r := range repos {
+		if keep(r) {
+			kept = append(kept, r)
+		}
+	}
+
+	if limit > 0 && len(kept) > limit {
+		kept = kept[:limit]
+	}
+
+	return kept, nil
+}
+
+// queryIndexedRepositories selects the repositories of the index matching
+// all the where conditions, a limit of zero selects every repository.
+func queryIndexedRepositories(ctx context.Context, from string, where []string, order string, limit int, params []interface{}) ([]repository, error) {
+	query := "SELECT " + repositoryColumns + " FROM " + from
+
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	query += " ORDER BY " + order
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	loggerFrom(ctx).Debug("offline", "query", query, "params", params)
+
+	db, err := openIndex(ctx)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return nil, errors.New("failed to open the local index")
+	}
+	defer db.Close()
+
+	rows, err := db.Query(query, params...)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return nil, errors.New("failed to search the local index")
+	}
+
+	return scanRepositories(rows)
+}
+
+// offlineUsers searches the logins, names and bios of the users in the local
+// index in offline mode.
+func offlineUsers(ctx context.Context, term string) ([]string, error) {
+	db, err := openIndex(ctx)
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return nil, errors.New("failed to open the local index")
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT u.login FROM users_fts JOIN users u ON u.rowid = users_fts.rowid
+		WHERE users_fts MATCH ? ORDER BY bm25(users_fts) LIMIT 30`, ftsQuery(term))
+	if err != nil {
+		loggerFrom(ctx).Debug("index", "err", err)
+		return nil, errors.New("failed to search the local index")
+	}
+	defer rows.Close()
+
+	logins := make([]string, 0)
+
+	for rows.Next() {
+		var login string
+
+		err := rows.Scan(&login)
+		if err != nil {
+			return nil, err
+		}
+
+		logins = append(logins, login)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(logins) == 0 {
+		return nil, fmt.Errorf("no users matching '%s' in the local index, run the search once without -offline", term)
+	}
+
+	return logins, nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var orgUsage = `Specify an org command to execute:
+  - repos: List the repositories of an organization
+  - members: List the members of an organization
+  - teams: List the teams of an organization
+  - team members: List the members of a team`
+
+func executeOrg(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(orgUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("org", "command", command)
+
+	if piped(args[1:]) {
+		return forEachIdentifier(args[1:], func(args []string) error {
+			return executeOrg(ctx, append([]string{command}, args...))
+		})
+	}
+
+	switch command {
+	case "repos":
+		return executeOrgRepos(ctx, args[1:])
+	case "members":
+		return executeOrgMembers(ctx, args[1:])
+	case "teams":
+		return executeOrgTeams(ctx, args[1:])
+	case "team":
+		return executeOrgTeam(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid org command: '%s'", command))
+	}
+}
+
+func executeOrgRepos(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("org repos", flag.ExitOnError)
+
+	filter := addRepoFilterFlags(flagSet)
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Organization")
+
+	if len(args) == 0 {
+		return usageError("provide an organization: org repos <org>")
+	}
+
+	err := filter.validate()
+	if err != nil {
+		return err
+	}
+
+	org, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("org repos", "org", org, "filter", *filter)
+
+	if *offline {
+		repos, err := offlineOwnerRepositories(ctx, org, filter.sort, page.max(), filter.keep)
+		if err != nil {
+			return err
+		}
+
+		return printRepositories(repos)
+	}
+
+	// Private repositories are included when the token has access to them.
+	repos, err := collectPages(ctx, githubClient().ListOrgRepositories(ctx, org, filter.sort), page.max(), filter.keep)
+	if err != nil {
+		return err
+	}
+
+	indexRepositories(ctx, repos)
+
+	return printRepositories(repos)
+}
+
+func executeOrgMembers(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("org members", flag.ExitOnError)
+
+	role := flagSet.String("role", "all", "only show members with a role: all, admin or member")
+	twoFactorDisabled := flagSet.Bool("2fa-disabled", false, "only show members without two factor authentication, requires owner access")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Organization")
+
+	if len(args) == 0 {
+		return usageError("provide an organization: org members <org>")
+	}
+
+	if *role != "all" && *role != "admin" && *role != "member" {
+		return usageErrorf("invalid role '%s', expected all, admin or member", *role)
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	org, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("org members", "org", org, "role", *role, "2fa_disabled", *twoFactorDisabled)
+
+	opts := &github.MemberOptions{Role: *role, TwoFactorDisabled: *twoFactorDisabled}
+
+	members, err := collectPages(ctx, githubClient().ListMembers(ctx, org, opts), page.max(), nil)
+	if errors.Is(err, errForbidden) && *twoFactorDisabled {
+		return fmt.Errorf("filtering by two factor authentication requires owner access to %s", org)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(members)
+	}
+
+	for _, m := range members {
+		fmt.Println(m.Login)
+	}
+
+	return nil
+}
+
+type team = github.Team
+
+func executeOrgTeams(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("org teams", flag.ExitOnError)
+
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Organization")
+
+	if len(args) == 0 {
+		return usageError("provide an organization: org teams <org>")
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	org, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("org teams", "org", org)
+
+	teams, err := collectPages(ctx, githubClient().ListTeams(ctx, org), page.max(), nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(teams)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SLUG\tNAME\tPRIVACY\tDESCRIPTION")
+
+	for _, t := range teams {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Slug, t.Name, t.Privacy, truncate(t.Description, 60))
+	}
+
+	return w.Flush()
+}
+
+func executeOrgTeam(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "members" {
+		return usageError("specify a team command to execute: org team members <org>/<team>")
+	}
+
+	flagSet := flag.NewFlagSet("org team members", flag.ExitOnError)
+
+	role := flagSet.String("role", "all", "only show members with a role: all, maintainer or member")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args[1:])
+
+	args = promptMissing(args, "Team (org/team)")
+
+	if len(args) == 0 {
+		return usageError("provide a team: org team members <org>/<team>")
+	}
+
+	org, slug, found := strings.Cut(args[0], "/")
+	if !found || org == "" || slug == "" {
+		return usageErrorf("invalid team '%s', expected org/team", args[0])
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("org team members", "org", org, "team", slug, "role", *role)
+
+	members, err := collectPages(ctx, githubClient().ListTeamMembers(ctx, org, slug, *role), page.max(), nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(members)
+	}
+
+	for _, m := range members {
+		fmt.Println(m.Login)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// jsonOutput reports whether results should be printed as json instead of
+// the default human readable table.
+func jsonOutput() bool {
+	return *format == "json"
+}
+
+// colorOutput reports whether stdout is a terminal that should get ansi
+// colors, honoring the NO_COLOR convention.
+func colorOutput() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	return isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file.
+// The null device is a character device too, so it is ruled out by hand.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+
+	null, err := os.Stat(os.DevNull)
+
+	return err != nil || !os.SameFile(info, null)
+}
+
+// bold returns s in bold when stdout gets colors, like the titles of the
+// views.
+func bold(s string) string {
+	if !colorOutput() {
+		return s
+	}
+
+	return ansiBold + s + ansiReset
+}
+
+// printJSON prints v as indented json to stdout.
+func printJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders the values as a single line of block characters scaled
+// to the largest value.
+func sparkline(values []int) string {
+	max := 0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+
+	for _, v := range values {
+		i := 0
+		if max > 0 {
+			i = v * (len(sparkBlocks) - 1) / max
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+
+	return b.String()
+}
+
+// truncate shortens s to at most n runes, marking cut off text with "...".
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n-3]) + "..."
+}
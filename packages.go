@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var packagesUsage = `Specify a packages command to execute:
+  - list: List the packages of a user or organization
+  - versions: List the versions of a package`
+
+func executePackages(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(packagesUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("packages", "command", command)
+
+	switch command {
+	case "list":
+		return executePackagesList(ctx, args[1:])
+	case "versions":
+		return executePackagesVersions(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid packages command: '%s'", command))
+	}
+}
+
+var packageTypes = []string{"container", "npm", "maven", "rubygems", "docker", "nuget"}
+
+type (
+	githubPackage  = github.Package
+	packageVersion = github.PackageVersion
+)
+
+func versionTags(v packageVersion) string {
+	if v.Metadata.Container == nil || len(v.Metadata.Container.Tags) == 0 {
+		return "-"
+	}
+
+	return strings.Join(v.Metadata.Container.Tags, ", ")
+}
+
+// findPackageOwner looks up whether owner is a user or an organization,
+// they have separate packages endpoints.
+func findPackageOwner(ctx context.Context, client *github.Client, owner string) (github.PackageOwner, error) {
+	account, err := client.GetPackageOwner(ctx, owner)
+
+	err = requestError(ctx, err)
+	if errors.Is(err, errNotFound) {
+		return account, fmt.Errorf("user or organization '%s' not found", owner)
+	}
+
+	return account, err
+}
+
+func validPackageType(packageType string) error {
+	if !containsString(packageTypes, packageType) {
+		return usageErrorf("invalid package type '%s', expected one of %s", packageType, strings.Join(packageTypes, ", "))
+	}
+
+	return nil
+}
+
+func executePackagesList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("packages list", flag.ExitOnError)
+
+	owner := flagSet.String("owner", "", "user or organization owning the packages")
+	packageType := flagSet.String("type", "container", "type of the packages: "+strings.Join(packageTypes, ", "))
+
+	parseArgs(flagSet, args)
+
+	if *owner == "" {
+		return usageError("provide an owner: packages list --owner <login>")
+	}
+
+	err := validPackageType(*packageType)
+	if err != nil {
+		return err
+	}
+
+	// The packages api needs a token with the read:packages scope even for
+	// public packages.
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("packages list", "owner", *owner, "type", *packageType)
+
+	client := githubClient()
+
+	account, err := findPackageOwner(ctx, client, *owner)
+	if err != nil {
+		return err
+	}
+
+	packages, err := collectPages(ctx, client.ListPackages(ctx, account, *packageType), 0, nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(packages)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVISIBILITY\tVERSIONS\tREPOSITORY\tUPDATED")
+
+	for _, p := range packages {
+		repository := "-"
+		if p.Repository != nil {
+			repository = p.Repository.FullName
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s ago\n", p.Name, p.Visibility, p.VersionCount, repository, formatAge(p.UpdatedAt))
+	}
+
+	return w.Flush()
+}
+
+func executePackagesVersions(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("packages versions", flag.ExitOnError)
+
+	owner := flagSet.String("owner", "", "user or organization owning the package")
+	packageType := flagSet.String("type", "container", "type of the package: "+strings.Join(packageTypes, ", "))
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+
+	if *owner == "" || len(args) == 0 {
+		return usageError("provide an owner and package: packages versions <name> --owner <login>")
+	}
+
+	err := validPackageType(*packageType)
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("packages versions", "owner", *owner, "type", *packageType, "package", args[0])
+
+	client := githubClient()
+
+	account, err := findPackageOwner(ctx, client, *owner)
+	if err != nil {
+		return err
+	}
+
+	versions, err := collectPages(ctx, client.ListPackageVersions(ctx, account, *packageType, args[0]), page.max(), nil)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("%s package '%s' not found for %s", *packageType, args[0], *owner)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(versions)
+	}
+
+	// The rest api has no sizes or download counts for package versions,
+	// only the tags and when a version was published.
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tVERSION\tTAGS\tCREATED")
+
+	for _, v := range versions {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s ago\n", v.ID, truncate(v.Name, 30), versionTags(v), formatAge(v.CreatedAt))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// pickItems lets the user fuzzy filter items and pick one or more of them,
+// tab marks multiple items. The picker draws on the terminal directly so
+// stdout only gets the picked items, e.g. in $(search-repos cli --pick).
+func pickItems(ctx context.Context, items []string) ([]string, error) {
+	if len(items) == 0 {
+		return nil, errors.New("nothing to pick from")
+	}
+
+	t, err := openTerminal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Close()
+
+	query := ""
+	cursor := 0
+	marked := make(map[string]bool)
+
+	for {
+		matches := fuzzyFilter(query, items)
+
+		if cursor >= len(matches) {
+			cursor = len(matches) - 1
+		}
+		if cursor < 0 {
+			cursor = 0
+		}
+
+		rows, cols := t.size()
+		t.draw(renderPicker(query, matches, len(items), cursor, marked, rows, cols))
+
+		key, err := t.readKey()
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "esc", "ctrl-c":
+			return nil, errors.New("nothing picked")
+		case "enter":
+			picked := make([]string, 0)
+
+			for _, item := range items {
+				if marked[item] {
+					picked = append(picked, item)
+				}
+			}
+
+			if len(picked) == 0 && len(matches) > 0 {
+				picked = append(picked, matches[cursor])
+			}
+
+			return picked, nil
+		case "up", "\x10":
+			cursor--
+		case "down", "\x0e":
+			cursor++
+		case "\t":
+			if len(matches) > 0 {
+				marked[matches[cursor]] = !marked[matches[cursor]]
+				cursor++
+			}
+		case "backspace":
+			if runes := []rune(query); len(runes) > 0 {
+				query = string(runes[:len(runes)-1])
+			}
+		case "\x15":
+			query = ""
+		default:
+			if isPrintable(key) {
+				query += key
+				cursor = 0
+			}
+		}
+	}
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func renderPicker(query string, matches []string, total, cursor int, marked map[string]bool, rows, cols int) []string {
+	lines := []string{
+		ansiBold + "> " + ansiReset + query,
+		ansiDim + fmt.Sprintf("%d/%d", len(matches), total) + ansiReset,
+	}
+
+	listRows := rows - len(lines)
+
+	// Scroll so the cursor stays on screen.
+	offset := 0
+	if cursor >= listRows {
+		offset = cursor - listRows + 1
+	}
+
+	for i := offset; i < len(matches) && i < offset+listRows; i++ {
+		prefix := "  "
+		if marked[matches[i]] {
+			prefix = "* "
+		}
+
+		line := truncate(prefix+matches[i], cols)
+		if i == cursor {
+			line = ansiReverse + line + ansiReset
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// fuzzyFilter returns the items containing the characters of query in order,
+// best matches first. An empty query keeps all items in their order.
+func fuzzyFilter(query string, items []string) []string {
+	type match struct {
+		item  string
+		score int
+	}
+
+	matches := make([]match, 0)
+
+	for _, item := range items {
+		score, ok := fuzzyScore(query, item)
+		if ok {
+			matches = append(matches, match{item, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.item
+	}
+
+	return result
+}
+
+// fuzzyScore matches query as a case insensitive subsequence of s. Matches
+// of consecutive characters and at the start of words score higher, so
+// "cli" ranks "cli/cli" above "c-lib-import".
+func fuzzyScore(query, s string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	runes := []rune(strings.ToLower(s))
+
+	score, qi, last := 0, 0, -2
+
+	for i, r := range runes {
+		if qi == len(q) {
+			break
+		}
+
+		if r != q[qi] {
+			continue
+		}
+
+		score++
+
+		if i == last+1 {
+			score += 5
+		}
+
+		if i == 0 || strings.ContainsRune("/-_. ", runes[i-1]) {
+			score += 8
+		}
+
+		last = i
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, false
+	}
+
+	// Prefer shorter items among equally good matches.
+	return score*100 - len(runes), true
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readIdentifiers reads the repositories, users or organizations piped to
+// stdin. They are separated by new lines, commas or spaces, so both the one
+// per line output of search-repos -q and the comma separated logins of
+// search-users can be piped.
+func readIdentifiers() ([]string, error) {
+	lines, err := readLines("-")
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		for _, field := range strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			if field != "-" {
+				identifiers = append(identifiers, field)
+			}
+		}
+	}
+
+	return identifiers, nil
+}
+
+// piped reports whether the arguments of a command start with a "-", which
+// stands for the identifiers piped to stdin.
+func piped(args []string) bool {
+	return len(args) > 0 && args[0] == "-"
+}
+
+// forEachIdentifier runs a command taking an identifier as its first
+// argument once for every identifier piped to stdin, with the "-" replaced.
+// A failing identifier is reported and the others still run.
+func forEachIdentifier(args []string, run func(args []string) error) error {
+	identifiers, err := readIdentifiers()
+	if err != nil {
+		return err
+	}
+
+	if len(identifiers) == 0 {
+		return errors.New("nothing piped to stdin, e.g. search-repos cli -q | repo view -")
+	}
+
+	failed := 0
+
+	for _, identifier := range identifiers {
+		err := run(append([]string{identifier}, args[1:]...))
+		if err != nil && !errors.Is(err, errDryRun) {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", identifier, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed for %d of %d identifiers", failed, len(identifiers))
+	}
+
+	return nil
+}
+
+// executePipeline runs commands separated by "|" arguments within one
+// invocation, the output of each command is the input of the next one,
+// e.g. search-repos golang -q '|' repo view -. A failing command ends the
+// pipeline.
+func executePipeline(ctx context.Context, args []string) error {
+	stages := [][]string{{}}
+
+	for _, arg := range args {
+		if arg == "|" {
+			stages = append(stages, []string{})
+			continue
+		}
+
+		stages[len(stages)-1] = append(stages[len(stages)-1], arg)
+	}
+
+	for i, stage := range stages {
+		if len(stage) == 0 {
+			return fmt.Errorf("command %d of the pipeline is empty", i+1)
+		}
+	}
+
+	stdin, stdout := os.Stdin, os.Stdout
+	defer func() {
+		os.Stdin, os.Stdout = stdin, stdout
+	}()
+
+	for i, stage := range stages {
+		if i == len(stages)-1 {
+			return executeCommand(ctx, stage[0], stage[1:])
+		}
+
+		loggerFrom(ctx).Debug("pipeline: running", "stage", i+1, "stages", len(stages), "command", stage)
+
+		output, err := captureStdout(func() error {
+			return executeCommand(ctx, stage[0], stage[1:])
+		})
+		if err != nil {
+			return err
+		}
+
+		os.Stdin, err = pipeInput(output)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// captureStdout runs f with the output written to os.Stdout collected.
+func captureStdout(f func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	var output bytes.Buffer
+	done := make(chan struct{})
+
+	go func() {
+		io.Copy(&output, r)
+		r.Close()
+		close(done)
+	}()
+
+	err = f()
+
+	os.Stdout = stdout
+	w.Close()
+	<-done
+
+	return output.Bytes(), err
+}
+
+// pipeInput returns a file reading data, to stand in for os.Stdin.
+func pipeInput(data []byte) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		w.Write(data)
+		w.Close()
+	}()
+
+	return r, nil
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// Actor is the account that triggered something, like a workflow run.
+type Actor struct {
+	Login string `json:"login"`
+}
+
+// WorkflowRun is a run of a github actions workflow. Conclusion is only set
+// once the Status is completed.
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	HeadBranch   string    `json:"head_branch"`
+	Event        string    `json:"event"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	HTMLURL      string    `json:"html_url"`
+	RunStartedAt time.Time `json:"run_started_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Actor        Actor     `json:"actor"`
+}
+
+// Result returns the conclusion of a completed run, the status otherwise.
+func (r WorkflowRun) Result() string {
+	if r.Status == "completed" {
+		return r.Conclusion
+	}
+
+	return r.Status
+}
+
+// Duration returns how long the run took, or has been running so far.
+func (r WorkflowRun) Duration() time.Duration {
+	end := r.UpdatedAt
+	if r.Status != "completed" {
+		end = time.Now()
+	}
+
+	return end.Sub(r.RunStartedAt).Round(time.Second)
+}
+
+// WorkflowStep is a step of a job.
+type WorkflowStep struct {
+	Name       string `json:"name"`
+	Number     int    `json:"number"`
+	Conclusion string `json:"conclusion"`
+}
+
+// WorkflowJob is a job of a workflow run.
+type WorkflowJob struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	Status     string         `json:"status"`
+	Conclusion string         `json:"conclusion"`
+	Steps      []WorkflowStep `json:"steps"`
+}
+
+// WorkflowRunOptions filter the runs of a repository, the zero value lists
+// all runs.
+type WorkflowRunOptions struct {
+	// Workflow only lists runs of a workflow, by file name or id, e.g.
+	// ci.yml.
+	Workflow string
+
+	Branch string
+
+	// Status only lists runs with a status or conclusion, e.g. failure or
+	// in_progress.
+	Status string
+}
+
+// ListWorkflowRuns lists the workflow runs of a repository, newest first.
+func (c *Client) ListWorkflowRuns(ctx context.Context, owner, repo string, opts *WorkflowRunOptions) *Pages[WorkflowRun] {
+	if opts == nil {
+		opts = &WorkflowRunOptions{}
+	}
+
+	path := repoPath(owner, repo) + "/actions/runs"
+	if opts.Workflow != "" {
+		path = fmt.Sprintf("%s/actions/workflows/%s/runs", repoPath(owner, repo), opts.Workflow)
+	}
+
+	query := url.Values{}
+	if opts.Branch != "" {
+		query.Set("branch", opts.Branch)
+	}
+	if opts.Status != "" {
+		query.Set("status", opts.Status)
+	}
+
+	return listPages[WorkflowRun](ctx, c, path, query, "workflow_runs")
+}
+
+// GetWorkflowRun returns a workflow run by its id.
+func (c *Client) GetWorkflowRun(ctx context.Context, owner, repo string, id int64) (*WorkflowRun, error) {
+	r := &WorkflowRun{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/actions/runs/%d", repoPath(owner, repo), id), nil, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ListWorkflowJobs lists the jobs of a workflow run.
+func (c *Client) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64) *Pages[WorkflowJob] {
+	return listPages[WorkflowJob](ctx, c, fmt.Sprintf("%s/actions/runs/%d/jobs", repoPath(owner, repo), runID), nil, "jobs")
+}
+
+// DownloadWorkflowRunLogs copies the logs of a workflow run to w, a zip
+// archive with a directory per job holding a "<number>_<step>.txt" file per
+// step. Github answers with a 404 once the logs expired.
+func (c *Client) DownloadWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, w io.Writer) error {
+	return c.GetRaw(ctx, fmt.Sprintf("%s/actions/runs/%d/logs", repoPath(owner, repo), runID), "application/vnd.github+json", w)
+}
@@ -0,0 +1,69 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListWorkflowRuns(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  *WorkflowRunOptions
+		path  string
+		query string
+	}{
+		{name: "all runs", path: "/repos/octocat/hello-world/actions/runs", query: "per_page=100"},
+		{name: "runs of a workflow", opts: &WorkflowRunOptions{Workflow: "ci.yml", Branch: "main", Status: "failure"}, path: "/repos/octocat/hello-world/actions/workflows/ci.yml/runs", query: "branch=main&per_page=100&status=failure"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]request, 0)
+			client := recordingClient(t, http.StatusOK, `{"total_count": 2, "workflow_runs": [{"id": 2, "status": "in_progress"}, {"id": 1, "status": "completed", "conclusion": "success"}]}`, &sent)
+
+			runs, err := client.ListWorkflowRuns(context.Background(), "octocat", "hello-world", tt.opts).All(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(runs) != 2 || runs[0].Result() != "in_progress" || runs[1].Result() != "success" {
+				t.Errorf("runs = %+v", runs)
+			}
+			if sent[0].path != tt.path || sent[0].query != tt.query {
+				t.Errorf("request = %s?%s, want %s?%s", sent[0].path, sent[0].query, tt.path, tt.query)
+			}
+		})
+	}
+}
+
+func TestWorkflowRunDuration(t *testing.T) {
+	started := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	run := WorkflowRun{Status: "completed", RunStartedAt: started, UpdatedAt: started.Add(90*time.Second + 300*time.Millisecond)}
+	if run.Duration() != 90*time.Second {
+		t.Errorf("duration = %s, want 1m30s", run.Duration())
+	}
+
+	// A running run has been running until now, not until its last update.
+	run = WorkflowRun{Status: "in_progress", RunStartedAt: time.Now().Add(-time.Hour), UpdatedAt: time.Now().Add(-59 * time.Minute)}
+	if run.Duration() < time.Hour {
+		t.Errorf("duration = %s, want at least an hour", run.Duration())
+	}
+}
+
+func TestDownloadWorkflowRunLogs(t *testing.T) {
+	sent := make([]request, 0)
+	out := &bytes.Buffer{}
+
+	err := recordingClient(t, http.StatusOK, "PK", &sent).DownloadWorkflowRunLogs(context.Background(), "octocat", "hello-world", 42, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.String() != "PK" || sent[0].path != "/repos/octocat/hello-world/actions/runs/42/logs" {
+		t.Errorf("downloaded %q from %s", out, sent[0].path)
+	}
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+	"net/url"
+)
+
+// Advisory is a security advisory of the github advisory database or of a
+// repository.
+type Advisory struct {
+	GHSAID          string          `json:"ghsa_id"`
+	Summary         string          `json:"summary"`
+	Severity        string          `json:"severity"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// Vulnerability is a package affected by an advisory.
+type Vulnerability struct {
+	Package                VulnerablePackage `json:"package"`
+	VulnerableVersionRange string            `json:"vulnerable_version_range"`
+	PatchedVersions        string            `json:"patched_versions"`
+}
+
+// VulnerablePackage is a package of an ecosystem, e.g. go or npm.
+type VulnerablePackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// AdvisoryOptions filter the advisory database, the zero value lists every
+// reviewed advisory.
+type AdvisoryOptions struct {
+	Ecosystem string
+	Severity  string
+
+	// Affects only lists advisories affecting a package.
+	Affects string
+
+	CVEID string
+}
+
+// ListAdvisories lists the reviewed advisories of the github advisory
+// database.
+func (c *Client) ListAdvisories(ctx context.Context, opts *AdvisoryOptions) *Pages[Advisory] {
+	if opts == nil {
+		opts = &AdvisoryOptions{}
+	}
+
+	query := url.Values{}
+	query.Set("type", "reviewed")
+
+	if opts.Ecosystem != "" {
+		query.Set("ecosystem", opts.Ecosystem)
+	}
+	if opts.Severity != "" {
+		query.Set("severity", opts.Severity)
+	}
+	if opts.Affects != "" {
+		query.Set("affects", opts.Affects)
+	}
+	if opts.CVEID != "" {
+		query.Set("cve_id", opts.CVEID)
+	}
+
+	return listPages[Advisory](ctx, c, "/advisories", query, "")
+}
+
+// ListRepositoryAdvisories lists the security advisories a repository
+// published.
+func (c *Client) ListRepositoryAdvisories(ctx context.Context, owner, repo string) *Pages[Advisory] {
+	return listPages[Advisory](ctx, c, repoPath(owner, repo)+"/security-advisories", nil, "")
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CheckRun is a check of a github app for a commit, Conclusion is only set
+// once the Status is completed.
+type CheckRun struct {
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// CommitStatus is a status reported for a commit through the statuses api,
+// its State is pending, success, failure or error.
+type CommitStatus struct {
+	Context   string    `json:"context"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CombinedStatus is the latest status of every context of a commit and
+// their combined State.
+type CombinedStatus struct {
+	State    string         `json:"state"`
+	Statuses []CommitStatus `json:"statuses"`
+}
+
+// ListCheckRuns returns the check runs of ref, a sha, branch or tag.
+func (c *Client) ListCheckRuns(ctx context.Context, owner, repo, ref string) ([]CheckRun, error) {
+	runs := struct {
+		CheckRuns []CheckRun `json:"check_runs"`
+	}{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/commits/%s/check-runs", repoPath(owner, repo), escapePath(ref)), nil, &runs)
+	if err != nil {
+		return nil, err
+	}
+
+	return runs.CheckRuns, nil
+}
+
+// GetCombinedStatus returns the statuses of ref, a sha, branch or tag.
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, repo, ref string) (*CombinedStatus, error) {
+	s := &CombinedStatus{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/commits/%s/status", repoPath(owner, repo), escapePath(ref)), nil, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
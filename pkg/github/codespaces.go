@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RepositoryName is a repository referenced by other resources.
+type RepositoryName struct {
+	FullName string `json:"full_name"`
+}
+
+// Codespace is a codespace of the user of the token.
+type Codespace struct {
+	Name        string            `json:"name"`
+	DisplayName string            `json:"display_name"`
+	State       string            `json:"state"`
+	LastUsedAt  time.Time         `json:"last_used_at"`
+	WebURL      string            `json:"web_url"`
+	Machine     *CodespaceMachine `json:"machine"`
+	Repository  RepositoryName    `json:"repository"`
+	GitStatus   struct {
+		Ref string `json:"ref"`
+	} `json:"git_status"`
+}
+
+// CodespaceMachine is the machine type of a codespace, nil until one is
+// assigned.
+type CodespaceMachine struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// ListCodespaces lists the codespaces of the user of the token.
+func (c *Client) ListCodespaces(ctx context.Context) *Pages[Codespace] {
+	return listPages[Codespace](ctx, c, "/user/codespaces", nil, "codespaces")
+}
+
+// ListRepositoryCodespaces lists the codespaces of the user of the token
+// for a repository.
+func (c *Client) ListRepositoryCodespaces(ctx context.Context, owner, repo string) *Pages[Codespace] {
+	return listPages[Codespace](ctx, c, repoPath(owner, repo)+"/codespaces", nil, "codespaces")
+}
+
+// StopCodespace stops a running codespace.
+func (c *Client) StopCodespace(ctx context.Context, name string) error {
+	return c.send(ctx, http.MethodPost, fmt.Sprintf("/user/codespaces/%s/stop", name), nil, nil)
+}
+
+// DeleteCodespace deletes a codespace with its uncommitted changes.
+func (c *Client) DeleteCodespace(ctx context.Context, name string) error {
+	return c.send(ctx, http.MethodDelete, "/user/codespaces/"+name, nil, nil)
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// CommitAuthor is the git author of a commit.
+type CommitAuthor struct {
+	Name string    `json:"name"`
+	Date time.Time `json:"date"`
+}
+
+// GitCommit is the git data of a commit.
+type GitCommit struct {
+	Message string       `json:"message"`
+	Author  CommitAuthor `json:"author"`
+}
+
+// CommitStats are the lines a commit changed.
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// Commit is a commit of a repository, the stats and files are only set
+// when fetched on its own.
+type Commit struct {
+	SHA    string        `json:"sha"`
+	Commit GitCommit     `json:"commit"`
+	Stats  CommitStats   `json:"stats"`
+	Files  []ChangedFile `json:"files"`
+}
+
+// ChangedFile is a file changed by a commit or between two commits, Status
+// is e.g. added, modified or removed.
+type ChangedFile struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// Comparison is the difference between two commits. Status is ahead,
+// behind, diverged or identical.
+type Comparison struct {
+	Status   string        `json:"status"`
+	AheadBy  int           `json:"ahead_by"`
+	BehindBy int           `json:"behind_by"`
+	Commits  []Commit      `json:"commits"`
+	Files    []ChangedFile `json:"files"`
+}
+
+// GetCommit returns a commit with its stats and changed files, ref is a
+// sha, branch or tag.
+func (c *Client) GetCommit(ctx context.Context, owner, repo, ref string) (*Commit, error) {
+	commit := &Commit{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/commits/%s", repoPath(owner, repo), escapePath(ref)), nil, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	return commit, nil
+}
+
+// GetCommitPatch copies the commit as a patch like git format-patch makes
+// to w.
+func (c *Client) GetCommitPatch(ctx context.Context, owner, repo, ref string, w io.Writer) error {
+	return c.GetRaw(ctx, fmt.Sprintf("%s/commits/%s", repoPath(owner, repo), escapePath(ref)), "application/vnd.github.patch", w)
+}
+
+// CompareCommits compares two commits given as "base...head".
+func (c *Client) CompareCommits(ctx context.Context, owner, repo, basehead string) (*Comparison, error) {
+	comparison := &Comparison{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/compare/%s", repoPath(owner, repo), escapePath(basehead)), nil, comparison)
+	if err != nil {
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+// GetComparisonDiff copies the diff between two commits given as
+// "base...head" to w.
+func (c *Client) GetComparisonDiff(ctx context.Context, owner, repo, basehead string, w io.Writer) error {
+	return c.GetRaw(ctx, fmt.Sprintf("%s/compare/%s", repoPath(owner, repo), escapePath(basehead)), "application/vnd.github.diff", w)
+}
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is an activity of a user or in a repository, the Payload depends on
+// the Type, e.g. PushEvent or WatchEvent for a star.
+type Event struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Actor Actor  `json:"actor"`
+	Repo  struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ListUserEvents lists the public events of a user, newest first.
+func (c *Client) ListUserEvents(ctx context.Context, login string) *Pages[Event] {
+	return listPages[Event](ctx, c, fmt.Sprintf("/users/%s/events/public", login), nil, "")
+}
+
+// ListRepositoryEvents lists the events of a repository, newest first.
+func (c *Client) ListRepositoryEvents(ctx context.Context, owner, repo string) *Pages[Event] {
+	return listPages[Event](ctx, c, repoPath(owner, repo)+"/events", nil, "")
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"net/http"
+)
+
+// GistFile is the content of a file of a gist.
+type GistFile struct {
+	Content string `json:"content"`
+}
+
+// GistRequest is a gist to create, Files maps the file names to their
+// content. Gists are secret unless Public.
+type GistRequest struct {
+	Description string              `json:"description"`
+	Public      bool                `json:"public"`
+	Files       map[string]GistFile `json:"files"`
+}
+
+// Gist is a created gist.
+type Gist struct {
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateGist creates a gist for the user of the token.
+func (c *Client) CreateGist(ctx context.Context, gist *GistRequest) (*Gist, error) {
+	created := &Gist{}
+
+	err := c.send(ctx, http.MethodPost, "/gists", gist, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,260 @@
+// Package github is the client of the github rest and graphql apis
+// go-cli-flag is built on, for other go programs to use repositories, users,
+// organizations, issues and actions without the cli. Lists github paginates
+// are fetched with Pages, requests the client has no method for are sent
+// with NewRequest and Do.
+//
+// Example:
+//
+//	client := github.NewClient(github.Options{Token: os.Getenv("GITHUB_TOKEN")})
+//
+//	result, err := client.SearchRepositories(ctx, "topic:cli language:go", &github.SearchOptions{Sort: "stars"})
+//
+//	branches, err := client.ListBranches(ctx, "golang", "go").All(0)
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is the url of the github api.
+const DefaultBaseURL = "https://api.github.com"
+
+// ErrInvalidResponse is wrapped by the errors of responses that did not
+// decode.
+var ErrInvalidResponse = errors.New("invalid response")
+
+// Options configures a Client, the zero value sends unauthenticated
+// requests to the github api with http.DefaultTransport.
+type Options struct {
+	// BaseURL is the url of the api, DefaultBaseURL when empty, e.g. the
+	// api of a github enterprise server.
+	BaseURL string
+
+	// Token is sent as the bearer token of every request.
+	Token string
+
+	// Transport sends the requests, http.DefaultTransport when nil. It is
+	// the place for logging, caching, retries or a fake api in tests.
+	Transport http.RoundTripper
+
+	// UserAgent is sent as the User-Agent of every request.
+	UserAgent string
+}
+
+// Client sends requests to the github api.
+type Client struct {
+	baseURL   string
+	token     string
+	userAgent string
+	http      *http.Client
+}
+
+// NewClient returns a client following the options.
+func NewClient(opts Options) *Client {
+	baseURL := strings.TrimSuffix(opts.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Client{
+		baseURL:   baseURL,
+		token:     opts.Token,
+		userAgent: opts.UserAgent,
+		http:      &http.Client{Transport: opts.Transport},
+	}
+}
+
+// NewRequest returns a request to path of the api, like /user, with the
+// query and the headers of the client. An io.Reader body is sent as is,
+// any other non nil body is encoded as json.
+func (c *Client) NewRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	var reader io.Reader
+
+	switch body := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = body
+	default:
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %v", err)
+	}
+
+	if query != nil {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// Do sends req and decodes the json response into out, a nil out discards
+// it. Responses with a status other than 2xx return an *Error. The response
+// is returned whenever github answered, with its body closed.
+func (c *Client) Do(req *http.Request, out interface{}) (*http.Response, error) {
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	err = CheckResponse(res)
+	if err != nil {
+		return res, err
+	}
+
+	if out == nil {
+		return res, nil
+	}
+
+	err = json.NewDecoder(res.Body).Decode(out)
+	if err != nil {
+		return res, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	return res, nil
+}
+
+// Get fetches path with the query and decodes the json response into out.
+func (c *Client) Get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, out)
+
+	return err
+}
+
+// Open sends req and returns the response with its body unread, for the
+// caller to stream and close, like archives and logs. Responses with a
+// status other than 2xx return an *Error.
+func (c *Client) Open(req *http.Request) (*http.Response, error) {
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = CheckResponse(res)
+	if err != nil {
+		res.Body.Close()
+		return res, err
+	}
+
+	return res, nil
+}
+
+// GetRaw fetches path requesting mediaType instead of json, e.g.
+// application/vnd.github.diff, and copies the body to w.
+func (c *Client) GetRaw(ctx context.Context, path, mediaType string, w io.Writer) error {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", mediaType)
+
+	res, err := c.Open(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+
+	return err
+}
+
+// send sends body encoded as json to path with method and decodes the json
+// response into out, a nil body sends an empty request.
+func (c *Client) send(ctx context.Context, method, path string, body, out interface{}) error {
+	req, err := c.NewRequest(ctx, method, path, nil, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Do(req, out)
+
+	return err
+}
+
+// Error is a response of github with a status other than 2xx, with the
+// details github gave in its body.
+type Error struct {
+	// Response is the response, its body is read already.
+	Response *http.Response `json:"-"`
+
+	Message          string       `json:"message"`
+	Errors           []FieldError `json:"errors"`
+	DocumentationURL string       `json:"documentation_url"`
+}
+
+// FieldError is the detail of a failed validation, e.g. of a missing
+// field.
+type FieldError struct {
+	Resource string `json:"resource"`
+	Field    string `json:"field"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	message := e.Response.Status
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+
+	if e.Response.Request == nil {
+		return message
+	}
+
+	return fmt.Sprintf("%s %s: %s", e.Response.Request.Method, e.Response.Request.URL.Path, message)
+}
+
+// CheckResponse returns an *Error for a response with a status other than
+// 2xx, reading its body, and nil otherwise. Bodies that are not json, like
+// those of proxies, leave the details empty.
+func CheckResponse(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	e := &Error{Response: res}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+	if err == nil {
+		json.Unmarshal(data, e)
+	}
+
+	return e
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// request is a request a fake api received, with its body read.
+type request struct {
+	method string
+	path   string
+	query  string
+	accept string
+	body   string
+}
+
+// recordingClient returns a client answering every request with status and
+// body, the requests are collected in sent.
+func recordingClient(t *testing.T, status int, body string, sent *[]request) *Client {
+	return NewClient(Options{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			r := request{method: req.Method, path: req.URL.EscapedPath(), query: req.URL.RawQuery, accept: req.Header.Get("Accept")}
+
+			if req.Body != nil {
+				data, err := io.ReadAll(req.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r.body = strings.TrimSpace(string(data))
+			}
+
+			*sent = append(*sent, r)
+
+			return respond(t, req, status, body)
+		}),
+	})
+}
+
+func TestNewRequest(t *testing.T) {
+	client := NewClient(Options{BaseURL: "https://github.example.com/api/v3/", Token: "test-token", UserAgent: "gcf-test"})
+
+	req, err := client.NewRequest(context.Background(), http.MethodPost, "/user/repos", nil, map[string]string{"name": "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.URL.String() != "https://github.example.com/api/v3/user/repos" {
+		t.Errorf("url = %s", req.URL)
+	}
+
+	for header, want := range map[string]string{
+		"Accept":        "application/vnd.github+json",
+		"Authorization": "Bearer test-token",
+		"User-Agent":    "gcf-test",
+		"Content-Type":  "application/json",
+	} {
+		if got := req.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	data, _ := io.ReadAll(req.Body)
+	if string(data) != `{"name":"hello"}` {
+		t.Errorf("body = %s", data)
+	}
+
+	req, err = NewClient(Options{}).NewRequest(context.Background(), http.MethodGet, "/user", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("Authorization") != "" || req.Header.Get("Content-Type") != "" || req.URL.Host != "api.github.com" {
+		t.Errorf("unauthenticated request = %s %v", req.URL, req.Header)
+	}
+}
+
+func TestGetRaw(t *testing.T) {
+	sent := make([]request, 0)
+	out := &bytes.Buffer{}
+
+	err := recordingClient(t, http.StatusOK, "diff --git a/go.mod b/go.mod\n", &sent).GetRaw(context.Background(), "/repos/octocat/hello-world/compare/main...dev", "application/vnd.github.diff", out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.String() != "diff --git a/go.mod b/go.mod\n" {
+		t.Errorf("out = %q", out)
+	}
+	if sent[0].accept != "application/vnd.github.diff" || sent[0].path != "/repos/octocat/hello-world/compare/main...dev" {
+		t.Errorf("request = %+v", sent[0])
+	}
+
+	out.Reset()
+
+	err = recordingClient(t, http.StatusNotFound, `{"message": "Not Found"}`, &sent).GetRaw(context.Background(), "/repos/octocat/hello-world/actions/runs/1/logs", "application/vnd.github+json", out)
+
+	e, ok := err.(*Error)
+	if !ok || e.Response.StatusCode != http.StatusNotFound {
+		t.Errorf("err = %v, want a 404 *Error", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("the error was written out: %q", out)
+	}
+}
+
+// closeRecorder is a body recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		closed bool
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "error", status: http.StatusForbidden, closed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &closeRecorder{Reader: strings.NewReader("archive")}
+
+			client := NewClient(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status), Header: http.Header{}, Body: body, Request: req}, nil
+			})})
+
+			res, err := client.OpenArchive(context.Background(), "octocat", "hello-world", "tarball", "v1.0.0")
+			if tt.status == http.StatusOK && err != nil {
+				t.Fatal(err)
+			}
+			if tt.status != http.StatusOK && err == nil {
+				t.Fatal("err = nil, want an *Error")
+			}
+
+			if res.Request.URL.Path != "/repos/octocat/hello-world/tarball/v1.0.0" {
+				t.Errorf("path = %s", res.Request.URL.Path)
+			}
+			if body.closed != tt.closed {
+				t.Errorf("closed = %t, want %t", body.closed, tt.closed)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	sent := make([]request, 0)
+
+	_, err := recordingClient(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed", "errors": [{"resource": "Label", "code": "already_exists", "field": "name"}]}`, &sent).CreateLabel(context.Background(), "octocat", "hello-world", Label{Name: "bug"})
+
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("err = %v, want an *Error", err)
+	}
+	if e.Error() != "POST /repos/octocat/hello-world/labels: Unprocessable Entity: Validation Failed" {
+		t.Errorf("message = %q", e.Error())
+	}
+	if len(e.Errors) != 1 || e.Errors[0].Code != "already_exists" {
+		t.Errorf("errors = %+v", e.Errors)
+	}
+}
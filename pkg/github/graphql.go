@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GraphQLError is an error of a graphql query, github answers queries that
+// failed with a 200 and the errors in the body.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Type    string        `json:"type"`
+	Path    []interface{} `json:"path"`
+}
+
+// GraphQLErrors are the errors github answered a query with.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	if len(e) == 0 {
+		return "graphql query failed"
+	}
+
+	if len(e) > 1 {
+		return fmt.Sprintf("%s (and %d more errors)", e[0].Message, len(e)-1)
+	}
+
+	return e[0].Message
+}
+
+// NotFound reports whether the query failed because something it asked for,
+// like the owner or number of a project, does not exist.
+func (e GraphQLErrors) NotFound() bool {
+	for _, err := range e {
+		if err.Type == "NOT_FOUND" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notFound is the error of a query answered without the node it asked for
+// and without errors.
+func notFound(format string, a ...interface{}) error {
+	return GraphQLErrors{{Message: fmt.Sprintf(format, a...), Type: "NOT_FOUND"}}
+}
+
+// PageInfo is the position of a page of a graphql connection, EndCursor is
+// the after argument of the next page.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQL runs a graphql query with the variables and decodes the data of
+// the response into out. Errors github answered the query with are returned
+// as GraphQLErrors. The graphql api always requires a token.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodPost, "/graphql", nil, body)
+	if err != nil {
+		return err
+	}
+
+	res := struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}{}
+
+	_, err = c.Do(req, &res)
+	if err != nil {
+		return err
+	}
+
+	if len(res.Errors) > 0 {
+		return res.Errors
+	}
+
+	err = json.Unmarshal(res.Data, out)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	return nil
+}
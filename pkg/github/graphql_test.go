@@ -0,0 +1,186 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// graphQLClient returns a client answering every query with body, the
+// decoded requests are collected in sent.
+func graphQLClient(t *testing.T, status int, body string, sent *[]map[string]interface{}) *Client {
+	return NewClient(Options{
+		Token: "test-token",
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodPost || req.URL.Path != "/graphql" {
+				t.Errorf("request = %s %s, want POST /graphql", req.Method, req.URL.Path)
+			}
+
+			data, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			query := make(map[string]interface{})
+
+			err = json.Unmarshal(data, &query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			*sent = append(*sent, query)
+
+			return respond(t, req, status, body)
+		}),
+	})
+}
+
+func TestGraphQL(t *testing.T) {
+	sent := make([]map[string]interface{}, 0)
+	client := graphQLClient(t, http.StatusOK, `{"data": {"viewer": {"login": "octocat"}}}`, &sent)
+
+	result := struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}{}
+
+	err := client.GraphQL(context.Background(), "query($n: Int!) { viewer { login } }", map[string]interface{}{"n": 1}, &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Viewer.Login != "octocat" {
+		t.Errorf("login = %q, want octocat", result.Viewer.Login)
+	}
+	if sent[0]["query"] != "query($n: Int!) { viewer { login } }" || !reflect.DeepEqual(sent[0]["variables"], map[string]interface{}{"n": 1.0}) {
+		t.Errorf("request = %v", sent[0])
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		message  string
+		notFound bool
+	}{
+		{name: "not found", body: `{"data": {"user": null}, "errors": [{"type": "NOT_FOUND", "path": ["user"], "message": "Could not resolve to a User with the login of 'nobody'."}]}`, message: "Could not resolve to a User with the login of 'nobody'.", notFound: true},
+		{name: "several", body: `{"errors": [{"message": "Field 'nope' doesn't exist on type 'User'"}, {"message": "Variable $login is declared but not used"}]}`, message: "Field 'nope' doesn't exist on type 'User' (and 1 more errors)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]map[string]interface{}, 0)
+
+			err := graphQLClient(t, http.StatusOK, tt.body, &sent).GraphQL(context.Background(), "{ viewer { login } }", nil, &struct{}{})
+
+			var errs GraphQLErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("err = %v, want GraphQLErrors", err)
+			}
+			if err.Error() != tt.message || errs.NotFound() != tt.notFound {
+				t.Errorf("err = %q, not found = %t", err, errs.NotFound())
+			}
+		})
+	}
+}
+
+func TestGraphQLFailures(t *testing.T) {
+	sent := make([]map[string]interface{}, 0)
+
+	err := graphQLClient(t, http.StatusUnauthorized, `{"message": "Bad credentials"}`, &sent).GraphQL(context.Background(), "{ viewer { login } }", nil, &struct{}{})
+
+	e, ok := err.(*Error)
+	if !ok || e.Response.StatusCode != http.StatusUnauthorized || e.Message != "Bad credentials" {
+		t.Errorf("err = %v, want a 401 *Error", err)
+	}
+
+	result := struct {
+		Viewer string `json:"viewer"`
+	}{}
+
+	err = graphQLClient(t, http.StatusOK, `{"data": {"viewer": {"login": "octocat"}}}`, &sent).GraphQL(context.Background(), "{ viewer { login } }", nil, &result)
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("err = %v, want ErrInvalidResponse", err)
+	}
+}
+
+func TestGetSponsorship(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		sponsorship *Sponsorship
+		notFound    bool
+	}{
+		{
+			name:        "listing",
+			body:        `{"data": {"user": {"login": "octocat", "hasSponsorsListing": true, "sponsors": {"totalCount": 12}, "sponsorsListing": {"tiers": {"nodes": [{"name": "$5 a month", "monthlyPriceInDollars": 5, "isOneTime": false}]}}}}}`,
+			sponsorship: &Sponsorship{Login: "octocat", Sponsorable: true, Sponsors: 12, Tiers: []SponsorTier{{Name: "$5 a month", MonthlyPriceInDollars: 5}}},
+		},
+		{
+			name:        "no listing",
+			body:        `{"data": {"user": {"login": "octocat", "hasSponsorsListing": false, "sponsors": {"totalCount": 0}, "sponsorsListing": null}}}`,
+			sponsorship: &Sponsorship{Login: "octocat", Tiers: []SponsorTier{}},
+		},
+		{name: "missing user", body: `{"data": {"user": null}}`, notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]map[string]interface{}, 0)
+
+			s, err := graphQLClient(t, http.StatusOK, tt.body, &sent).GetSponsorship(context.Background(), "octocat")
+
+			if tt.notFound {
+				var errs GraphQLErrors
+				if !errors.As(err, &errs) || !errs.NotFound() {
+					t.Errorf("err = %v, want a not found error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(s, tt.sponsorship) {
+				t.Errorf("sponsorship = %+v, want %+v", s, tt.sponsorship)
+			}
+			if !reflect.DeepEqual(sent[0]["variables"], map[string]interface{}{"login": "octocat"}) {
+				t.Errorf("variables = %v", sent[0]["variables"])
+			}
+		})
+	}
+}
+
+func TestListPullRequests(t *testing.T) {
+	sent := make([]map[string]interface{}, 0)
+	body := `{"data": {"repository": {"pullRequests": {"pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"}, "nodes": [
+		{"number": 7, "title": "Fix the parser", "reviewRequests": {"nodes": [{"requestedReviewer": {"slug": "core"}}]}, "commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "SUCCESS"}}}]}},
+		{"number": 6, "title": "Add a flag", "commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}}
+	]}}}}`
+
+	prs, pageInfo, err := graphQLClient(t, http.StatusOK, body, &sent).ListPullRequests(context.Background(), "octocat", "hello-world", &PullRequestOptions{States: []string{"OPEN"}}, "Y3Vyc29x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(prs) != 2 || pageInfo != (PageInfo{HasNextPage: true, EndCursor: "Y3Vyc29y"}) {
+		t.Fatalf("prs = %+v, page = %+v", prs, pageInfo)
+	}
+	if prs[0].CIState() != "SUCCESS" || prs[1].CIState() != "" {
+		t.Errorf("ci states = %q, %q", prs[0].CIState(), prs[1].CIState())
+	}
+	if !prs[0].RequestedFrom("Core") || prs[1].RequestedFrom("core") {
+		t.Error("review requested from the wrong pull request")
+	}
+
+	variables := sent[0]["variables"].(map[string]interface{})
+	if variables["owner"] != "octocat" || variables["name"] != "hello-world" || variables["after"] != "Y3Vyc29x" || variables["labels"] != nil {
+		t.Errorf("variables = %v", variables)
+	}
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Label is a label of the issues of a repository, Color is hex without the
+// leading #, e.g. d73a4a.
+type Label struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// Issue is an issue of a repository. Github lists pull requests as issues
+// as well, with PullRequest set.
+type Issue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	State       string    `json:"state"`
+	HTMLURL     string    `json:"html_url"`
+	User        User      `json:"user"`
+	Labels      []Label   `json:"labels"`
+	Assignees   []User    `json:"assignees"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	PullRequest *struct{} `json:"pull_request,omitempty"`
+}
+
+// IsPullRequest reports whether the issue is a pull request.
+func (i Issue) IsPullRequest() bool {
+	return i.PullRequest != nil
+}
+
+// IssueRequest is an issue to create.
+type IssueRequest struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+// IssueListOptions filter the issues of a repository, the zero value lists
+// the open ones.
+type IssueListOptions struct {
+	// State is open, closed or all.
+	State string
+
+	// Labels only lists issues with all of the labels.
+	Labels []string
+
+	// Assignee only lists issues assigned to a login, none for issues
+	// without one and * for those with any.
+	Assignee string
+}
+
+// Milestone is a milestone of a repository, DueOn is nil for milestones
+// without a due date.
+type Milestone struct {
+	Number       int        `json:"number"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	State        string     `json:"state"`
+	OpenIssues   int        `json:"open_issues"`
+	ClosedIssues int        `json:"closed_issues"`
+	DueOn        *time.Time `json:"due_on"`
+	HTMLURL      string     `json:"html_url"`
+}
+
+// Completion returns the share of closed issues, a milestone without
+// issues counts as not started.
+func (m Milestone) Completion() float64 {
+	total := m.OpenIssues + m.ClosedIssues
+	if total == 0 {
+		return 0
+	}
+
+	return float64(m.ClosedIssues) / float64(total)
+}
+
+// CreateIssue creates an issue, labels and assignees are only set with push
+// access to the repository.
+func (c *Client) CreateIssue(ctx context.Context, owner, repo string, req *IssueRequest) (*Issue, error) {
+	i := &Issue{}
+
+	err := c.send(ctx, http.MethodPost, repoPath(owner, repo)+"/issues", req, i)
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// ListIssues lists the issues of a repository, recently created first. The
+// pull requests are part of the list, see Issue.IsPullRequest.
+func (c *Client) ListIssues(ctx context.Context, owner, repo string, opts *IssueListOptions) *Pages[Issue] {
+	query := url.Values{}
+
+	if opts != nil {
+		if opts.State != "" {
+			query.Set("state", opts.State)
+		}
+		if len(opts.Labels) > 0 {
+			query.Set("labels", strings.Join(opts.Labels, ","))
+		}
+		if opts.Assignee != "" {
+			query.Set("assignee", opts.Assignee)
+		}
+	}
+
+	return listPages[Issue](ctx, c, repoPath(owner, repo)+"/issues", query, "")
+}
+
+// ListLabels lists the labels of a repository.
+func (c *Client) ListLabels(ctx context.Context, owner, repo string) *Pages[Label] {
+	return listPages[Label](ctx, c, repoPath(owner, repo)+"/labels", nil, "")
+}
+
+// CreateLabel creates a label in a repository.
+func (c *Client) CreateLabel(ctx context.Context, owner, repo string, label Label) (*Label, error) {
+	l := &Label{}
+
+	err := c.send(ctx, http.MethodPost, repoPath(owner, repo)+"/labels", label, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// UpdateLabel replaces the label name of a repository with label, which
+// renames it when the names differ.
+func (c *Client) UpdateLabel(ctx context.Context, owner, repo, name string, label Label) (*Label, error) {
+	l := &Label{}
+
+	err := c.send(ctx, http.MethodPatch, repoPath(owner, repo)+"/labels/"+url.PathEscape(name), label, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// DeleteLabel deletes a label of a repository and removes it from its
+// issues.
+func (c *Client) DeleteLabel(ctx context.Context, owner, repo, name string) error {
+	return c.send(ctx, http.MethodDelete, repoPath(owner, repo)+"/labels/"+url.PathEscape(name), nil, nil)
+}
+
+// ListMilestones lists the milestones of a repository in a state of open,
+// closed or all, by due date.
+func (c *Client) ListMilestones(ctx context.Context, owner, repo, state string) *Pages[Milestone] {
+	query := url.Values{}
+	query.Set("state", state)
+	query.Set("sort", "due_on")
+
+	return listPages[Milestone](ctx, c, repoPath(owner, repo)+"/milestones", query, "")
+}
+
+// GetMilestone returns a milestone of a repository by its number.
+func (c *Client) GetMilestone(ctx context.Context, owner, repo string, number int) (*Milestone, error) {
+	m := &Milestone{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/milestones/%d", repoPath(owner, repo), number), nil, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestIssueRequests(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want request
+		body string
+	}{
+		{
+			name: "create",
+			call: func(c *Client) error {
+				_, err := c.CreateIssue(ctx, "octocat", "hello-world", &IssueRequest{Title: "Crash on start", Labels: []string{"bug"}})
+				return err
+			},
+			want: request{method: "POST", path: "/repos/octocat/hello-world/issues", body: `{"title":"Crash on start","body":"","labels":["bug"]}`},
+		},
+		{
+			name: "list with filters",
+			call: func(c *Client) error {
+				_, err := c.ListIssues(ctx, "octocat", "hello-world", &IssueListOptions{State: "all", Labels: []string{"bug", "good first issue"}, Assignee: "none"}).Next()
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/issues", query: "assignee=none&labels=bug%2Cgood+first+issue&per_page=100&state=all"},
+			body: "[]",
+		},
+		{
+			name: "update a label with a space",
+			call: func(c *Client) error {
+				_, err := c.UpdateLabel(ctx, "octocat", "hello-world", "good first issue", Label{Name: "good first issue", Color: "7057ff"})
+				return err
+			},
+			want: request{method: "PATCH", path: "/repos/octocat/hello-world/labels/good%20first%20issue", body: `{"name":"good first issue","color":"7057ff","description":""}`},
+		},
+		{
+			name: "delete a label",
+			call: func(c *Client) error {
+				return c.DeleteLabel(ctx, "octocat", "hello-world", "wontfix")
+			},
+			want: request{method: "DELETE", path: "/repos/octocat/hello-world/labels/wontfix"},
+		},
+		{
+			name: "milestones by due date",
+			call: func(c *Client) error {
+				_, err := c.ListMilestones(ctx, "octocat", "hello-world", "open").Next()
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/milestones", query: "per_page=100&sort=due_on&state=open"},
+			body: "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]request, 0)
+
+			if tt.body == "" {
+				tt.body = "{}"
+			}
+
+			err := tt.call(recordingClient(t, http.StatusOK, tt.body, &sent))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.accept = "application/vnd.github+json"
+
+			if len(sent) != 1 || sent[0] != tt.want {
+				t.Errorf("sent = %+v, want %+v", sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestListIssuesPullRequests(t *testing.T) {
+	sent := make([]request, 0)
+
+	issues, err := recordingClient(t, http.StatusOK, `[{"number": 2, "pull_request": {"url": "https://api.github.com/repos/octocat/hello-world/pulls/2"}}, {"number": 1}]`, &sent).ListIssues(context.Background(), "octocat", "hello-world", nil).All(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(issues) != 2 || !issues[0].IsPullRequest() || issues[1].IsPullRequest() {
+		t.Errorf("issues = %+v", issues)
+	}
+	if sent[0].query != "per_page=100" {
+		t.Errorf("query = %q, want only per_page", sent[0].query)
+	}
+}
+
+func TestMilestoneCompletion(t *testing.T) {
+	tests := []struct {
+		open, closed int
+		want         float64
+	}{
+		{open: 0, closed: 0, want: 0},
+		{open: 3, closed: 1, want: 0.25},
+		{open: 0, closed: 5, want: 1},
+	}
+
+	for _, tt := range tests {
+		m := Milestone{OpenIssues: tt.open, ClosedIssues: tt.closed}
+
+		if got := m.Completion(); got != tt.want {
+			t.Errorf("completion of %d open and %d closed = %v, want %v", tt.open, tt.closed, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Notification is a notification of the user of the token about a thread
+// like an issue or pull request.
+type Notification struct {
+	ID         string         `json:"id"`
+	Reason     string         `json:"reason"`
+	Unread     bool           `json:"unread"`
+	Repository RepositoryName `json:"repository"`
+	Subject    struct {
+		Title string `json:"title"`
+		Type  string `json:"type"`
+	} `json:"subject"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ListNotifications lists the unread notifications of the user of the
+// token, only those of threads they participate in or are mentioned in when
+// participating.
+func (c *Client) ListNotifications(ctx context.Context, participating bool) *Pages[Notification] {
+	query := url.Values{}
+	if participating {
+		query.Set("participating", "true")
+	}
+
+	return listPages[Notification](ctx, c, "/notifications", query, "")
+}
+
+// MarkThreadRead marks the notification of a thread as read.
+func (c *Client) MarkThreadRead(ctx context.Context, id string) error {
+	return c.send(ctx, http.MethodPatch, "/notifications/threads/"+id, nil, nil)
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Team is a team of an organization.
+type Team struct {
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Privacy     string `json:"privacy"`
+	Description string `json:"description"`
+}
+
+// MemberOptions filter the members of an organization, the zero value
+// lists all of them.
+type MemberOptions struct {
+	// Role is all, admin or member.
+	Role string
+
+	// TwoFactorDisabled only lists members without two factor
+	// authentication, it requires owner access.
+	TwoFactorDisabled bool
+}
+
+// ListOrgRepositories lists the repositories of an organization, sorted by
+// created, updated, pushed or full_name. Private repositories are included
+// when the token has access to them.
+func (c *Client) ListOrgRepositories(ctx context.Context, org, sort string) *Pages[Repository] {
+	query := url.Values{}
+	query.Set("type", "all")
+	query.Set("sort", sort)
+
+	return listPages[Repository](ctx, c, fmt.Sprintf("/orgs/%s/repos", org), query, "")
+}
+
+// ListMembers lists the members of an organization.
+func (c *Client) ListMembers(ctx context.Context, org string, opts *MemberOptions) *Pages[User] {
+	query := url.Values{}
+
+	if opts != nil && opts.Role != "" {
+		query.Set("role", opts.Role)
+	}
+	if opts != nil && opts.TwoFactorDisabled {
+		query.Set("filter", "2fa_disabled")
+	}
+
+	return listPages[User](ctx, c, fmt.Sprintf("/orgs/%s/members", org), query, "")
+}
+
+// ListTeams lists the teams of an organization visible to the token.
+func (c *Client) ListTeams(ctx context.Context, org string) *Pages[Team] {
+	return listPages[Team](ctx, c, fmt.Sprintf("/orgs/%s/teams", org), nil, "")
+}
+
+// ListTeamMembers lists the members of a team with a role of all,
+// maintainer or member, all when empty.
+func (c *Client) ListTeamMembers(ctx context.Context, org, slug, role string) *Pages[User] {
+	query := url.Values{}
+	if role != "" {
+		query.Set("role", role)
+	}
+
+	return listPages[User](ctx, c, fmt.Sprintf("/orgs/%s/teams/%s/members", org, slug), query, "")
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Package is a package published to the github packages registry.
+type Package struct {
+	Name         string          `json:"name"`
+	PackageType  string          `json:"package_type"`
+	Visibility   string          `json:"visibility"`
+	VersionCount int             `json:"version_count"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	HTMLURL      string          `json:"html_url"`
+	Repository   *RepositoryName `json:"repository"`
+}
+
+// PackageVersion is a published version of a package, the tags are only set
+// for containers.
+type PackageVersion struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	HTMLURL   string    `json:"html_url"`
+	Metadata  struct {
+		Container *struct {
+			Tags []string `json:"tags"`
+		} `json:"container"`
+	} `json:"metadata"`
+}
+
+// PackageOwner is the user or organization owning packages, they have
+// separate endpoints.
+type PackageOwner struct {
+	Login        string
+	Organization bool
+}
+
+// GetPackageOwner looks up whether login is a user or an organization.
+func (c *Client) GetPackageOwner(ctx context.Context, login string) (PackageOwner, error) {
+	account := struct {
+		Type string `json:"type"`
+	}{}
+
+	err := c.Get(ctx, "/users/"+login, nil, &account)
+	if err != nil {
+		return PackageOwner{}, err
+	}
+
+	return PackageOwner{Login: login, Organization: account.Type == "Organization"}, nil
+}
+
+func (o PackageOwner) path() string {
+	if o.Organization {
+		return "/orgs/" + o.Login + "/packages"
+	}
+
+	return "/users/" + o.Login + "/packages"
+}
+
+// ListPackages lists the packages of a type, e.g. container or npm. The
+// packages api needs a token with the read:packages scope even for public
+// packages.
+func (c *Client) ListPackages(ctx context.Context, owner PackageOwner, packageType string) *Pages[Package] {
+	query := url.Values{}
+	query.Set("package_type", packageType)
+
+	return listPages[Package](ctx, c, owner.path(), query, "")
+}
+
+// ListPackageVersions lists the versions of a package, newest first.
+func (c *Client) ListPackageVersions(ctx context.Context, owner PackageOwner, packageType, name string) *Pages[PackageVersion] {
+	return listPages[PackageVersion](ctx, c, fmt.Sprintf("%s/%s/%s/versions", owner.path(), packageType, url.PathEscape(name)), nil, "")
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var (
+	linkNext = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+	linkLast = regexp.MustCompile(`<([^>]+)>;\s*rel="last"`)
+)
+
+// NextPageURL returns the url of the next page from the link header of a
+// paginated response, it is empty on the last page.
+func NextPageURL(header http.Header) string {
+	match := linkNext.FindStringSubmatch(header.Get("Link"))
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// LastPage returns the number of the last page from the link header of a
+// paginated response, zero when it is not known.
+func LastPage(header http.Header) int {
+	match := linkLast.FindStringSubmatch(header.Get("Link"))
+	if match == nil {
+		return 0
+	}
+
+	last, err := url.Parse(match[1])
+	if err != nil {
+		return 0
+	}
+
+	n, _ := strconv.Atoi(last.Query().Get("page"))
+
+	return n
+}
+
+// Pages fetches a list github paginates page by page, following the link
+// headers of its responses. Items that do not decode, like a field of
+// another type than documented, are left out and counted instead of failing
+// the whole list.
+//
+// Example:
+//
+//	pages := client.ListBranches(ctx, "golang", "go")
+//
+//	for !pages.Done() {
+//		branches, err := pages.Next()
+//		...
+//	}
+type Pages[T any] struct {
+	client  *Client
+	req     *http.Request
+	field   string
+	err     error
+	res     *http.Response
+	done    bool
+	skipped int
+}
+
+// NewPages returns the pages of the list req fetches, for requests the
+// methods of the client do not cover or that need their own headers, which
+// are sent with every page. Field is the name of the list in the response
+// of endpoints that wrap it in an object, like workflow_runs, and empty
+// for those answering with the list itself.
+func NewPages[T any](c *Client, req *http.Request, field string) *Pages[T] {
+	return &Pages[T]{client: c, req: req, field: field}
+}
+
+// listPages returns the pages of path with 100 items a page, the most
+// github sends. An invalid request is returned by the first Next.
+func listPages[T any](ctx context.Context, c *Client, path string, query url.Values, field string) *Pages[T] {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	query.Set("per_page", "100")
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, query, nil)
+
+	return &Pages[T]{client: c, req: req, field: field, err: err}
+}
+
+// PerPage sets the number of items of a page, at most 100, before the first
+// page is fetched.
+func (p *Pages[T]) PerPage(n int) {
+	if p.req == nil || p.res != nil {
+		return
+	}
+
+	query := p.req.URL.Query()
+	query.Set("per_page", strconv.Itoa(n))
+	p.req.URL.RawQuery = query.Encode()
+}
+
+// Done reports whether the last page was fetched.
+func (p *Pages[T]) Done() bool {
+	return p.done
+}
+
+// URL returns the url of the next page, empty when the last page was
+// fetched. It is the cursor to continue an interrupted fetch with Resume.
+func (p *Pages[T]) URL() string {
+	if p.req == nil || p.done {
+		return ""
+	}
+
+	return p.req.URL.String()
+}
+
+// Resume continues the fetch with the page of rawURL, a url returned by URL.
+func (p *Pages[T]) Resume(rawURL string) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	next, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid page url: %v", err)
+	}
+
+	p.req = p.req.Clone(p.req.Context())
+	p.req.URL = next
+	p.done = false
+
+	return nil
+}
+
+// Response returns the response of the last page fetched, nil before the
+// first page, e.g. for its rate limit headers.
+func (p *Pages[T]) Response() *http.Response {
+	return p.res
+}
+
+// Skipped returns the number of items left out of the pages fetched so far
+// because they did not decode.
+func (p *Pages[T]) Skipped() int {
+	return p.skipped
+}
+
+// Next fetches the next page and returns its items. After the last page it
+// returns no items.
+func (p *Pages[T]) Next() ([]T, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	if p.done {
+		return []T{}, nil
+	}
+
+	raw := make([]json.RawMessage, 0)
+
+	var res *http.Response
+	var err error
+
+	if p.field == "" {
+		res, err = p.client.Do(p.req, &raw)
+	} else {
+		wrapped := make(map[string]json.RawMessage)
+
+		res, err = p.client.Do(p.req, &wrapped)
+		if err == nil && len(wrapped[p.field]) > 0 {
+			err = json.Unmarshal(wrapped[p.field], &raw)
+			if err != nil {
+				err = fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.res = res
+
+	items := make([]T, 0, len(raw))
+
+	for _, r := range raw {
+		var item T
+
+		if json.Unmarshal(r, &item) != nil {
+			p.skipped++
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	next := NextPageURL(res.Header)
+	if next == "" || len(raw) == 0 {
+		p.done = true
+		return items, nil
+	}
+
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid next page url: %w", ErrInvalidResponse, err)
+	}
+
+	p.req = p.req.Clone(p.req.Context())
+	p.req.URL = nextURL
+
+	return items, nil
+}
+
+// All fetches pages until limit items are collected, a limit of zero
+// fetches every page.
+func (p *Pages[T]) All(limit int) ([]T, error) {
+	if limit > 0 && limit < 100 {
+		p.PerPage(limit)
+	}
+
+	items := make([]T, 0)
+
+	for !p.Done() {
+		page, err := p.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+	}
+
+	return items, nil
+}
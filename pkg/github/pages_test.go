@@ -0,0 +1,323 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		next string
+		last int
+	}{
+		{name: "first page", link: `<https://api.github.com/user/repos?page=2>; rel="next", <https://api.github.com/user/repos?page=5>; rel="last"`, next: "https://api.github.com/user/repos?page=2", last: 5},
+		{name: "last page", link: `<https://api.github.com/user/repos?page=1>; rel="first", <https://api.github.com/user/repos?page=4>; rel="prev"`},
+		{name: "no link"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.link != "" {
+				header.Set("Link", tt.link)
+			}
+
+			if next := NextPageURL(header); next != tt.next {
+				t.Errorf("next = %q, want %q", next, tt.next)
+			}
+			if last := LastPage(header); last != tt.last {
+				t.Errorf("last = %d, want %d", last, tt.last)
+			}
+		})
+	}
+}
+
+// pagedClient returns a client answering with pages of n items of a list of
+// total users, linking to the next page.
+func pagedClient(t *testing.T, total int, sent *[]*http.Request) *Client {
+	return NewClient(Options{
+		BaseURL: "https://api.example.com",
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*sent = append(*sent, req)
+
+			page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+			if page == 0 {
+				page = 1
+			}
+			perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+
+			body := "["
+			for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+				if i > (page-1)*perPage {
+					body += ","
+				}
+				body += fmt.Sprintf(`{"login": "user%d"}`, i)
+			}
+			body += "]"
+
+			res, err := respond(t, req, http.StatusOK, body)
+			if page*perPage < total {
+				next := *req.URL
+				query := next.Query()
+				query.Set("page", strconv.Itoa(page+1))
+				next.RawQuery = query.Encode()
+
+				res.Header.Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+			}
+
+			return res, err
+		}),
+	})
+}
+
+func TestPagesAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		limit   int
+		count   int
+		perPage string
+		pages   int
+	}{
+		{name: "every page", total: 250, count: 250, perPage: "100", pages: 3},
+		{name: "limit below a page", total: 250, limit: 30, count: 30, perPage: "30", pages: 1},
+		{name: "limit over pages", total: 250, limit: 150, count: 150, perPage: "100", pages: 2},
+		{name: "empty list", total: 0, count: 0, perPage: "100", pages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]*http.Request, 0)
+			client := pagedClient(t, tt.total, &sent)
+
+			users, err := client.ListFollowers(context.Background(), "octocat").All(tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(users) != tt.count {
+				t.Errorf("users = %d, want %d", len(users), tt.count)
+			}
+			if len(sent) != tt.pages {
+				t.Errorf("requests = %d, want %d", len(sent), tt.pages)
+			}
+			for _, req := range sent {
+				if req.URL.Path != "/users/octocat/followers" || req.URL.Query().Get("per_page") != tt.perPage {
+					t.Errorf("request = %s", req.URL)
+				}
+			}
+		})
+	}
+}
+
+func TestPagesNext(t *testing.T) {
+	sent := make([]*http.Request, 0)
+	pages := pagedClient(t, 150, &sent).ListFollowers(context.Background(), "octocat")
+
+	if pages.Response() != nil || pages.Done() {
+		t.Fatal("pages fetched before the first Next")
+	}
+
+	first := pages.URL()
+
+	users, err := pages.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 100 || users[0].Login != "user0" || pages.Done() {
+		t.Fatalf("first page = %d users, done = %t", len(users), pages.Done())
+	}
+	if pages.Response() == nil || pages.URL() == first {
+		t.Errorf("url = %s after the first page", pages.URL())
+	}
+
+	users, err = pages.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 50 || users[0].Login != "user100" || !pages.Done() || pages.URL() != "" {
+		t.Errorf("second page = %d users, done = %t, url = %q", len(users), pages.Done(), pages.URL())
+	}
+
+	users, err = pages.Next()
+	if err != nil || len(users) != 0 || len(sent) != 2 {
+		t.Errorf("after the last page: users = %v, err = %v, requests = %d", users, err, len(sent))
+	}
+}
+
+func TestPagesResume(t *testing.T) {
+	sent := make([]*http.Request, 0)
+	client := pagedClient(t, 250, &sent)
+
+	pages := client.ListFollowers(context.Background(), "octocat")
+
+	_, err := pages.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cursor := pages.URL()
+
+	resumed := client.ListFollowers(context.Background(), "octocat")
+
+	err = resumed.Resume(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := resumed.All(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 150 || users[0].Login != "user100" {
+		t.Errorf("resumed = %d users from %v", len(users), users[0])
+	}
+	if sent[1].URL.String() != cursor {
+		t.Errorf("resumed at %s, want %s", sent[1].URL, cursor)
+	}
+	if sent[1].Header.Get("Accept") != "application/vnd.github+json" {
+		t.Errorf("resumed without the headers of the client: %v", sent[1].Header)
+	}
+}
+
+func TestPagesDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		logins  []string
+		skipped int
+		invalid bool
+	}{
+		{name: "missing list", data: `{}`, logins: []string{}},
+		{name: "null list", data: `{"workflow_runs": null}`, logins: []string{}},
+		{name: "empty list", data: `{"workflow_runs": []}`, logins: []string{}},
+		{name: "null item", data: `{"workflow_runs": [null, {"login": "octocat"}]}`, logins: []string{"", "octocat"}},
+		{name: "item of another type", data: `{"workflow_runs": ["octocat", {"login": "octocat"}]}`, logins: []string{"octocat"}, skipped: 1},
+		{name: "no list", data: `{"workflow_runs": {"login": "octocat"}}`, invalid: true},
+		{name: "no object", data: `[{"login": "octocat"}]`, invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return respond(t, req, http.StatusOK, tt.data)
+			})})
+
+			req, err := client.NewRequest(context.Background(), http.MethodGet, "/list", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pages := NewPages[User](client, req, "workflow_runs")
+
+			users, err := pages.All(0)
+
+			if tt.invalid {
+				if !errors.Is(err, ErrInvalidResponse) {
+					t.Errorf("err = %v, want ErrInvalidResponse", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			logins := make([]string, 0)
+			for _, u := range users {
+				logins = append(logins, u.Login)
+			}
+
+			if !reflect.DeepEqual(logins, tt.logins) {
+				t.Errorf("logins = %v, want %v", logins, tt.logins)
+			}
+			if pages.Skipped() != tt.skipped {
+				t.Errorf("skipped = %d, want %d", pages.Skipped(), tt.skipped)
+			}
+		})
+	}
+}
+
+func TestPagesRecorded(t *testing.T) {
+	tests := []struct {
+		file    string
+		names   []string
+		skipped int
+	}{
+		{file: "search_repositories.json", names: []string{"spf13/cobra", "urfave/cli"}},
+		// A count of another type and topics that are no list are left out.
+		{file: "search_repositories_invalid.json", names: []string{"spf13/cobra"}, skipped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			sent := make([]*http.Request, 0)
+			client := fakeClient(t, tt.file, &sent)
+
+			req, err := client.NewRequest(context.Background(), http.MethodGet, "/search/repositories", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pages := NewPages[Repository](client, req, "items")
+
+			repos, err := pages.All(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0)
+			for _, r := range repos {
+				names = append(names, r.FullName)
+			}
+
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("names = %v, want %v", names, tt.names)
+			}
+			if pages.Skipped() != tt.skipped {
+				t.Errorf("skipped = %d, want %d", pages.Skipped(), tt.skipped)
+			}
+		})
+	}
+}
+
+func TestPagesErrors(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		client := NewClient(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return respond(t, req, http.StatusNotFound, `{"message": "Not Found"}`)
+		})})
+
+		pages := client.ListBranches(context.Background(), "octocat", "missing")
+
+		_, err := pages.Next()
+
+		e, ok := err.(*Error)
+		if !ok || e.Response.StatusCode != http.StatusNotFound || e.Message != "Not Found" {
+			t.Errorf("err = %v, want a 404 *Error", err)
+		}
+		if pages.Done() {
+			t.Error("done after a failed page")
+		}
+	})
+
+	t.Run("invalid next url", func(t *testing.T) {
+		client := NewClient(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := respond(t, req, http.StatusOK, `[{"name": "main"}]`)
+			res.Header.Set("Link", `<http://[::1>; rel="next"`)
+			return res, err
+		})})
+
+		_, err := client.ListBranches(context.Background(), "octocat", "hello-world").All(0)
+		if !errors.Is(err, ErrInvalidResponse) {
+			t.Errorf("err = %v, want ErrInvalidResponse", err)
+		}
+	})
+}
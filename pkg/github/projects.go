@@ -0,0 +1,259 @@
+package github
+
+import (
+	"context"
+	"strconv"
+)
+
+// Users and organizations both implement ProjectV2Owner, querying through
+// the interface works for either kind of owner.
+const projectsQuery = `query($owner: String!, $first: Int!, $after: String) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectsV2(first: $first, after: $after) {
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        nodes {
+          number
+          title
+          shortDescription
+          closed
+          url
+          items {
+            totalCount
+          }
+        }
+      }
+    }
+  }
+}`
+
+// Project is a project of a user or organization.
+type Project struct {
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	ShortDescription string `json:"shortDescription"`
+	Closed           bool   `json:"closed"`
+	URL              string `json:"url"`
+	Items            struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"items"`
+}
+
+// ListProjects returns a page of 50 projects of a user or organization,
+// after is the EndCursor of the previous page.
+func (c *Client) ListProjects(ctx context.Context, owner, after string) ([]Project, PageInfo, error) {
+	variables := map[string]interface{}{
+		"owner": owner,
+		"first": 50,
+	}
+
+	if after != "" {
+		variables["after"] = after
+	}
+
+	result := struct {
+		RepositoryOwner *struct {
+			ProjectsV2 struct {
+				PageInfo PageInfo  `json:"pageInfo"`
+				Nodes    []Project `json:"nodes"`
+			} `json:"projectsV2"`
+		} `json:"repositoryOwner"`
+	}{}
+
+	err := c.GraphQL(ctx, projectsQuery, variables, &result)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	if result.RepositoryOwner == nil {
+		return nil, PageInfo{}, notFound("could not resolve to a user or organization with the login of '%s'", owner)
+	}
+
+	return result.RepositoryOwner.ProjectsV2.Nodes, result.RepositoryOwner.ProjectsV2.PageInfo, nil
+}
+
+const projectItemsQuery = `query($owner: String!, $number: Int!, $first: Int!, $after: String) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        title
+        items(first: $first, after: $after) {
+          pageInfo {
+            hasNextPage
+            endCursor
+          }
+          nodes {
+            type
+            content {
+              ... on Issue {
+                title
+                number
+                url
+                repository {
+                  nameWithOwner
+                }
+              }
+              ... on PullRequest {
+                title
+                number
+                url
+                repository {
+                  nameWithOwner
+                }
+              }
+              ... on DraftIssue {
+                title
+              }
+            }
+            fieldValues(first: 30) {
+              nodes {
+                ... on ProjectV2ItemFieldSingleSelectValue {
+                  name
+                  field {
+                    ... on ProjectV2FieldCommon {
+                      name
+                    }
+                  }
+                }
+                ... on ProjectV2ItemFieldTextValue {
+                  text
+                  field {
+                    ... on ProjectV2FieldCommon {
+                      name
+                    }
+                  }
+                }
+                ... on ProjectV2ItemFieldNumberValue {
+                  number
+                  field {
+                    ... on ProjectV2FieldCommon {
+                      name
+                    }
+                  }
+                }
+                ... on ProjectV2ItemFieldDateValue {
+                  date
+                  field {
+                    ... on ProjectV2FieldCommon {
+                      name
+                    }
+                  }
+                }
+                ... on ProjectV2ItemFieldIterationValue {
+                  title
+                  field {
+                    ... on ProjectV2FieldCommon {
+                      name
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// ProjectFieldValue holds any of the field value types of a project item,
+// only the member matching the type of the field is set.
+type ProjectFieldValue struct {
+	Name   string   `json:"name"`
+	Text   string   `json:"text"`
+	Number *float64 `json:"number"`
+	Date   string   `json:"date"`
+	Title  string   `json:"title"`
+	Field  struct {
+		Name string `json:"name"`
+	} `json:"field"`
+}
+
+func (v ProjectFieldValue) String() string {
+	switch {
+	case v.Name != "":
+		return v.Name
+	case v.Text != "":
+		return v.Text
+	case v.Number != nil:
+		return strconv.FormatFloat(*v.Number, 'f', -1, 64)
+	case v.Date != "":
+		return v.Date
+	default:
+		return v.Title
+	}
+}
+
+// ProjectItem is an issue, pull request or draft issue of a project. Draft
+// issues only have a title.
+type ProjectItem struct {
+	Type    string `json:"type"`
+	Content struct {
+		Title      string `json:"title"`
+		Number     int    `json:"number"`
+		URL        string `json:"url"`
+		Repository struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+	} `json:"content"`
+	FieldValues struct {
+		Nodes []ProjectFieldValue `json:"nodes"`
+	} `json:"fieldValues"`
+}
+
+// Fields returns the values of the item by field name. The title is left
+// out as it is part of the content.
+func (i ProjectItem) Fields() map[string]string {
+	fields := make(map[string]string)
+
+	for _, v := range i.FieldValues.Nodes {
+		if v.Field.Name == "" || v.Field.Name == "Title" {
+			continue
+		}
+
+		fields[v.Field.Name] = v.String()
+	}
+
+	return fields
+}
+
+// ListProjectItems returns a page of 100 items of a project of a user or
+// organization, after is the EndCursor of the previous page.
+func (c *Client) ListProjectItems(ctx context.Context, owner string, number int, after string) ([]ProjectItem, PageInfo, error) {
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"number": number,
+		"first":  100,
+	}
+
+	if after != "" {
+		variables["after"] = after
+	}
+
+	result := struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				Items struct {
+					PageInfo PageInfo      `json:"pageInfo"`
+					Nodes    []ProjectItem `json:"nodes"`
+				} `json:"items"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}{}
+
+	err := c.GraphQL(ctx, projectItemsQuery, variables, &result)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	if result.RepositoryOwner == nil || result.RepositoryOwner.ProjectV2 == nil {
+		return nil, PageInfo{}, notFound("could not resolve to a project with the number %d of '%s'", number, owner)
+	}
+
+	items := result.RepositoryOwner.ProjectV2.Items
+
+	return items.Nodes, items.PageInfo, nil
+}
@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// The review decision and the ci status are not part of the rest pulls
+// endpoint, graphql gets them without a request per pull request.
+const pullRequestsQuery = `query($owner: String!, $name: String!, $first: Int!, $after: String, $labels: [String!], $states: [PullRequestState!]) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(first: $first, after: $after, labels: $labels, states: $states, orderBy: {field: CREATED_AT, direction: DESC}) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        number
+        title
+        url
+        isDraft
+        author {
+          login
+        }
+        baseRefName
+        headRefName
+        reviewDecision
+        reviewRequests(first: 20) {
+          nodes {
+            requestedReviewer {
+              ... on User {
+                login
+              }
+              ... on Team {
+                slug
+              }
+            }
+          }
+        }
+        commits(last: 1) {
+          nodes {
+            commit {
+              statusCheckRollup {
+                state
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// PullRequest is a pull request with its review decision and the combined
+// ci state of its latest commit.
+type PullRequest struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	IsDraft        bool   `json:"isDraft"`
+	Author         Actor  `json:"author"`
+	BaseRefName    string `json:"baseRefName"`
+	HeadRefName    string `json:"headRefName"`
+	ReviewDecision string `json:"reviewDecision"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login string `json:"login"`
+				Slug  string `json:"slug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// CIState returns the combined state of the checks of the latest commit,
+// e.g. SUCCESS, empty when it has no checks.
+func (pr PullRequest) CIState() string {
+	if len(pr.Commits.Nodes) == 0 || pr.Commits.Nodes[0].Commit.StatusCheckRollup == nil {
+		return ""
+	}
+
+	return pr.Commits.Nodes[0].Commit.StatusCheckRollup.State
+}
+
+// RequestedFrom reports whether a review was requested from the user or
+// team.
+func (pr PullRequest) RequestedFrom(reviewer string) bool {
+	for _, r := range pr.ReviewRequests.Nodes {
+		if strings.EqualFold(r.RequestedReviewer.Login, reviewer) || strings.EqualFold(r.RequestedReviewer.Slug, reviewer) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PullRequestOptions filter the pull requests of a repository.
+type PullRequestOptions struct {
+	// States are OPEN, CLOSED or MERGED, all of them when empty.
+	States []string
+
+	// Labels only lists pull requests with any of the labels.
+	Labels []string
+}
+
+// ListPullRequests returns a page of 50 pull requests of a repository,
+// newest first, after is the EndCursor of the previous page.
+func (c *Client) ListPullRequests(ctx context.Context, owner, repo string, opts *PullRequestOptions, after string) ([]PullRequest, PageInfo, error) {
+	if opts == nil {
+		opts = &PullRequestOptions{}
+	}
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"name":  repo,
+		"first": 50,
+	}
+
+	if len(opts.States) > 0 {
+		variables["states"] = opts.States
+	}
+	if len(opts.Labels) > 0 {
+		variables["labels"] = opts.Labels
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	result := struct {
+		Repository *struct {
+			PullRequests struct {
+				PageInfo PageInfo      `json:"pageInfo"`
+				Nodes    []PullRequest `json:"nodes"`
+			} `json:"pullRequests"`
+		} `json:"repository"`
+	}{}
+
+	err := c.GraphQL(ctx, pullRequestsQuery, variables, &result)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	if result.Repository == nil {
+		return nil, PageInfo{}, notFound("could not resolve to a repository with the name '%s/%s'", owner, repo)
+	}
+
+	return result.Repository.PullRequests.Nodes, result.Repository.PullRequests.PageInfo, nil
+}
+
+// PullRequestHead is the branch a pull request wants to merge. Repository is
+// the full name of the repository of the branch, empty when it was deleted.
+// Fork reports whether that is another repository than the one the pull
+// request is made to.
+type PullRequestHead struct {
+	Ref        string
+	Repository string
+	Fork       bool
+}
+
+// GetPullRequestHead returns the head branch of a pull request.
+func (c *Client) GetPullRequestHead(ctx context.Context, owner, repo string, number int) (*PullRequestHead, error) {
+	type branch struct {
+		Ref  string `json:"ref"`
+		Repo *struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	}
+
+	pr := struct {
+		Head branch `json:"head"`
+		Base branch `json:"base"`
+	}{}
+
+	err := c.Get(ctx, fmt.Sprintf("%s/pulls/%d", repoPath(owner, repo), number), nil, &pr)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repository of a deleted fork is null.
+	head := &PullRequestHead{Ref: pr.Head.Ref, Fork: true}
+
+	if pr.Head.Repo != nil {
+		head.Repository = pr.Head.Repo.FullName
+		head.Fork = pr.Base.Repo == nil || !strings.EqualFold(pr.Head.Repo.FullName, pr.Base.Repo.FullName)
+	}
+
+	return head, nil
+}
@@ -0,0 +1,49 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetPullRequestHead(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want PullRequestHead
+	}{
+		{
+			name: "same repository",
+			body: `{"head": {"ref": "fix-typo", "repo": {"full_name": "octocat/hello-world"}}, "base": {"ref": "main", "repo": {"full_name": "octocat/hello-world"}}}`,
+			want: PullRequestHead{Ref: "fix-typo", Repository: "octocat/hello-world"},
+		},
+		{
+			name: "fork",
+			body: `{"head": {"ref": "main", "repo": {"full_name": "hubot/hello-world"}}, "base": {"ref": "main", "repo": {"full_name": "octocat/hello-world"}}}`,
+			want: PullRequestHead{Ref: "main", Repository: "hubot/hello-world", Fork: true},
+		},
+		{
+			name: "deleted fork",
+			body: `{"head": {"ref": "main", "repo": null}, "base": {"ref": "main", "repo": {"full_name": "octocat/hello-world"}}}`,
+			want: PullRequestHead{Ref: "main", Fork: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]request, 0)
+
+			head, err := recordingClient(t, http.StatusOK, tt.body, &sent).GetPullRequestHead(context.Background(), "octocat", "hello-world", 7)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if *head != tt.want {
+				t.Errorf("head = %+v, want %+v", *head, tt.want)
+			}
+			if sent[0].path != "/repos/octocat/hello-world/pulls/7" {
+				t.Errorf("path = %s", sent[0].path)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package github
+
+import "context"
+
+// RateLimit is the limit of requests of a resource like core or search,
+// Reset is the unix time the remaining requests reset at.
+type RateLimit struct {
+	Limit     int   `json:"limit"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+// GetRateLimits returns the rate limits by resource, checking them does not
+// count against them.
+func (c *Client) GetRateLimits(ctx context.Context) (map[string]RateLimit, error) {
+	limits := struct {
+		Resources map[string]RateLimit `json:"resources"`
+	}{}
+
+	err := c.Get(ctx, "/rate_limit", nil, &limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return limits.Resources, nil
+}
@@ -0,0 +1,596 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrDirectory is returned by GetFile for a path that is a directory.
+var ErrDirectory = errors.New("path is a directory")
+
+// StarMediaType makes the starring endpoints include when a star was given.
+const StarMediaType = "application/vnd.github.star+json"
+
+// CreatedRepository is a repository as github answers its creation or fork.
+type CreatedRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url"`
+	SSHURL   string `json:"ssh_url"`
+}
+
+// RepositoryRequest is a repository to create.
+type RepositoryRequest struct {
+	Name        string `json:"name"`
+	Private     bool   `json:"private"`
+	Description string `json:"description"`
+
+	// GitignoreTemplate and LicenseTemplate add an initial commit with the
+	// template, e.g. Go and mit.
+	GitignoreTemplate string `json:"gitignore_template,omitempty"`
+	LicenseTemplate   string `json:"license_template,omitempty"`
+}
+
+// ForkOptions are the options of a fork, the zero value forks into the
+// account of the token.
+type ForkOptions struct {
+	Organization string `json:"organization,omitempty"`
+}
+
+// CommitRef is the commit a branch or tag points to.
+type CommitRef struct {
+	SHA string `json:"sha"`
+}
+
+// Branch is a branch of a repository.
+type Branch struct {
+	Name      string    `json:"name"`
+	Commit    CommitRef `json:"commit"`
+	Protected bool      `json:"protected"`
+}
+
+// Tag is a tag of a repository.
+type Tag struct {
+	Name   string    `json:"name"`
+	Commit CommitRef `json:"commit"`
+}
+
+// Contributor is a contributor of a repository, anonymous contributors have
+// the Type Anonymous and a Name instead of a Login.
+type Contributor struct {
+	Login         string `json:"login"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Contributions int    `json:"contributions"`
+}
+
+// ReleaseAsset is a file attached to a release. URL is the asset in the
+// api, it downloads the file when requested as application/octet-stream,
+// with the token for assets of private repositories.
+type ReleaseAsset struct {
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	URL                string `json:"url"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Release is a release of a repository, PublishedAt is zero for drafts.
+type Release struct {
+	TagName     string         `json:"tag_name"`
+	Name        string         `json:"name"`
+	Draft       bool           `json:"draft"`
+	Prerelease  bool           `json:"prerelease"`
+	PublishedAt time.Time      `json:"published_at"`
+	Assets      []ReleaseAsset `json:"assets"`
+}
+
+// FileContent is a file of the contents api. Content is encoded with
+// Encoding, which is none for files larger than 1MB that have to be
+// downloaded from DownloadURL.
+type FileContent struct {
+	Content     string `json:"content"`
+	Encoding    string `json:"encoding"`
+	DownloadURL string `json:"download_url"`
+}
+
+// License is a license github knows, e.g. MIT.
+type License struct {
+	SPDXID string `json:"spdx_id"`
+	Name   string `json:"name"`
+}
+
+// RepositoryLicense is the license file of a repository and the license
+// github detected in it.
+type RepositoryLicense struct {
+	Content  string  `json:"content"`
+	Encoding string  `json:"encoding"`
+	License  License `json:"license"`
+}
+
+// TreeEntry is a file, a blob, or directory, a tree, of a tree.
+type TreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+// Tree is the tree of a commit, Truncated when it is too large for github
+// to list completely.
+type Tree struct {
+	Tree      []TreeEntry `json:"tree"`
+	Truncated bool        `json:"truncated"`
+}
+
+// Stargazer is a user who starred a repository and when.
+type Stargazer struct {
+	StarredAt time.Time `json:"starred_at"`
+	User      User      `json:"user"`
+}
+
+// StarredRepository is a repository a user starred and when.
+type StarredRepository struct {
+	StarredAt time.Time  `json:"starred_at"`
+	Repo      Repository `json:"repo"`
+}
+
+// CollaboratorPermissions are the permissions a collaborator has.
+type CollaboratorPermissions struct {
+	Admin    bool `json:"admin"`
+	Maintain bool `json:"maintain"`
+	Push     bool `json:"push"`
+	Triage   bool `json:"triage"`
+	Pull     bool `json:"pull"`
+}
+
+// Collaborator is a user with access to a repository. RoleName is the
+// role of the user, including custom roles of organizations.
+type Collaborator struct {
+	Login       string                  `json:"login"`
+	RoleName    string                  `json:"role_name"`
+	Permissions CollaboratorPermissions `json:"permissions"`
+}
+
+// Permission returns the highest permission level of the collaborator.
+func (c Collaborator) Permission() string {
+	if c.RoleName != "" {
+		return c.RoleName
+	}
+
+	switch p := c.Permissions; {
+	case p.Admin:
+		return "admin"
+	case p.Maintain:
+		return "maintain"
+	case p.Push:
+		return "write"
+	case p.Triage:
+		return "triage"
+	default:
+		return "read"
+	}
+}
+
+// Setting is a rule of a branch protection that is on or off.
+type Setting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// RequiredReviews is the rule of a protected branch to require reviews of
+// pull requests.
+type RequiredReviews struct {
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+}
+
+// RequiredStatusChecks is the rule of a protected branch to require checks
+// to pass, Strict requires branches to be up to date.
+type RequiredStatusChecks struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+// BranchProtection are the rules of a protected branch, rules that are not
+// configured are nil.
+type BranchProtection struct {
+	RequiredPullRequestReviews *RequiredReviews      `json:"required_pull_request_reviews"`
+	RequiredStatusChecks       *RequiredStatusChecks `json:"required_status_checks"`
+	EnforceAdmins              Setting               `json:"enforce_admins"`
+	RequiredSignatures         Setting               `json:"required_signatures"`
+}
+
+// TrafficDay are the views or clones of a day.
+type TrafficDay struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+	Uniques   int       `json:"uniques"`
+}
+
+// Traffic are the views or clones of a repository of the last 14 days.
+type Traffic struct {
+	Count   int          `json:"count"`
+	Uniques int          `json:"uniques"`
+	Days    []TrafficDay `json:"days"`
+}
+
+// Referrer is a site linking to a repository.
+type Referrer struct {
+	Referrer string `json:"referrer"`
+	Count    int    `json:"count"`
+	Uniques  int    `json:"uniques"`
+}
+
+func repoPath(owner, repo string) string {
+	return fmt.Sprintf("/repos/%s/%s", owner, repo)
+}
+
+// escapePath escapes the segments of a path within a repository, like a
+// file, branch or tag, keeping the slashes between them, so a # or ? in a
+// name does not end the path. A + is escaped too, github reads it as a
+// space.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(s), "+", "%2B")
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// GetRepository returns a repository.
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*Repository, error) {
+	r := &Repository{}
+
+	err := c.Get(ctx, repoPath(owner, repo), nil, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CreateRepository creates a repository in org, or in the account of the
+// token when org is empty.
+func (c *Client) CreateRepository(ctx context.Context, org string, req *RepositoryRequest) (*CreatedRepository, error) {
+	path := "/user/repos"
+	if org != "" {
+		path = fmt.Sprintf("/orgs/%s/repos", org)
+	}
+
+	r := &CreatedRepository{}
+
+	err := c.send(ctx, http.MethodPost, path, req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CreateFork forks a repository. Github creates the fork asynchronously, it
+// answers with a 404 for a while after.
+func (c *Client) CreateFork(ctx context.Context, owner, repo string, opts *ForkOptions) (*CreatedRepository, error) {
+	if opts == nil {
+		opts = &ForkOptions{}
+	}
+
+	r := &CreatedRepository{}
+
+	err := c.send(ctx, http.MethodPost, repoPath(owner, repo)+"/forks", opts, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// DeleteRepository deletes a repository, it requires admin access and a
+// token with the delete_repo scope.
+func (c *Client) DeleteRepository(ctx context.Context, owner, repo string) error {
+	return c.send(ctx, http.MethodDelete, repoPath(owner, repo), nil, nil)
+}
+
+// OpenArchive opens the tarball or zipball of a repository at ref, the
+// default branch when empty, for the caller to stream and close.
+func (c *Client) OpenArchive(ctx context.Context, owner, repo, format, ref string) (*http.Response, error) {
+	path := repoPath(owner, repo) + "/" + format
+	if ref != "" {
+		path += "/" + escapePath(ref)
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Open(req)
+}
+
+// ListBranches lists the branches of a repository.
+func (c *Client) ListBranches(ctx context.Context, owner, repo string) *Pages[Branch] {
+	return listPages[Branch](ctx, c, repoPath(owner, repo)+"/branches", nil, "")
+}
+
+// GetBranch returns a branch of a repository.
+func (c *Client) GetBranch(ctx context.Context, owner, repo, branch string) (*Branch, error) {
+	b := &Branch{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/branches/"+escapePath(branch), nil, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// GetBranchProtection returns the rules of a protected branch, it answers
+// with a 404 for branches that are not protected.
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*BranchProtection, error) {
+	p := &BranchProtection{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/branches/"+escapePath(branch)+"/protection", nil, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// ListTags lists the tags of a repository, newest first.
+func (c *Client) ListTags(ctx context.Context, owner, repo string) *Pages[Tag] {
+	return listPages[Tag](ctx, c, repoPath(owner, repo)+"/tags", nil, "")
+}
+
+// ListContributors lists the contributors by the number of contributions,
+// anonymous ones only with anon.
+func (c *Client) ListContributors(ctx context.Context, owner, repo string, anon bool) *Pages[Contributor] {
+	query := url.Values{}
+	if anon {
+		query.Set("anon", "1")
+	}
+
+	return listPages[Contributor](ctx, c, repoPath(owner, repo)+"/contributors", query, "")
+}
+
+// ListCollaborators lists the collaborators with an affiliation of direct,
+// outside or all, it requires push access to the repository.
+func (c *Client) ListCollaborators(ctx context.Context, owner, repo, affiliation string) *Pages[Collaborator] {
+	query := url.Values{}
+	query.Set("affiliation", affiliation)
+
+	return listPages[Collaborator](ctx, c, repoPath(owner, repo)+"/collaborators", query, "")
+}
+
+// ListStargazers lists the users who starred a repository, oldest first.
+func (c *Client) ListStargazers(ctx context.Context, owner, repo string) *Pages[Stargazer] {
+	pages := listPages[Stargazer](ctx, c, repoPath(owner, repo)+"/stargazers", nil, "")
+	if pages.req != nil {
+		pages.req.Header.Set("Accept", StarMediaType)
+	}
+
+	return pages
+}
+
+// Star stars a repository for the user of the token.
+func (c *Client) Star(ctx context.Context, owner, repo string) error {
+	return c.send(ctx, http.MethodPut, fmt.Sprintf("/user/starred/%s/%s", owner, repo), nil, nil)
+}
+
+// Unstar removes the star of the user of the token from a repository.
+func (c *Client) Unstar(ctx context.Context, owner, repo string) error {
+	return c.send(ctx, http.MethodDelete, fmt.Sprintf("/user/starred/%s/%s", owner, repo), nil, nil)
+}
+
+// GetLanguages returns the number of bytes of code of a repository by
+// language.
+func (c *Client) GetLanguages(ctx context.Context, owner, repo string) (map[string]int64, error) {
+	languages := make(map[string]int64)
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/languages", nil, &languages)
+	if err != nil {
+		return nil, err
+	}
+
+	return languages, nil
+}
+
+// GetTopics returns the topics of a repository.
+func (c *Client) GetTopics(ctx context.Context, owner, repo string) ([]string, error) {
+	topics := struct {
+		Names []string `json:"names"`
+	}{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/topics", nil, &topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return topics.Names, nil
+}
+
+// ReplaceTopics replaces all topics of a repository with names and returns
+// the topics github kept.
+func (c *Client) ReplaceTopics(ctx context.Context, owner, repo string, names []string) ([]string, error) {
+	topics := struct {
+		Names []string `json:"names"`
+	}{Names: names}
+
+	err := c.send(ctx, http.MethodPut, repoPath(owner, repo)+"/topics", topics, &topics)
+	if err != nil {
+		return nil, err
+	}
+
+	return topics.Names, nil
+}
+
+// GetLicense returns the license file of a repository, it answers with a
+// 404 when github detected none.
+func (c *Client) GetLicense(ctx context.Context, owner, repo string) (*RepositoryLicense, error) {
+	l := &RepositoryLicense{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/license", nil, l)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// GetReadme returns the readme of a repository.
+func (c *Client) GetReadme(ctx context.Context, owner, repo string) (*FileContent, error) {
+	f := &FileContent{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/readme", nil, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// GetFile returns a file of a repository at ref, the default branch when
+// empty. A path that is a directory returns ErrDirectory.
+func (c *Client) GetFile(ctx context.Context, owner, repo, path, ref string) (*FileContent, error) {
+	query := url.Values{}
+	if ref != "" {
+		query.Set("ref", ref)
+	}
+
+	// Directories are answered with a list of entries instead of a file.
+	var raw json.RawMessage
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/contents/"+escapePath(strings.Trim(path, "/")), query, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(string(raw), "[") {
+		return nil, ErrDirectory
+	}
+
+	f := &FileContent{}
+
+	err = json.Unmarshal(raw, f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	return f, nil
+}
+
+// GetTree returns the tree of ref, with the trees of sub directories when
+// recursive.
+func (c *Client) GetTree(ctx context.Context, owner, repo, ref string, recursive bool) (*Tree, error) {
+	query := url.Values{}
+	if recursive {
+		query.Set("recursive", "1")
+	}
+
+	t := &Tree{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/git/trees/"+escapePath(ref), query, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// GetSBOM returns the software bill of materials of a repository as the spdx
+// json document github generated.
+func (c *Client) GetSBOM(ctx context.Context, owner, repo string) (json.RawMessage, error) {
+	result := struct {
+		SBOM json.RawMessage `json:"sbom"`
+	}{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/dependency-graph/sbom", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.SBOM, nil
+}
+
+// ListReleases lists the releases of a repository newest first, drafts only
+// with push access.
+func (c *Client) ListReleases(ctx context.Context, owner, repo string) *Pages[Release] {
+	return listPages[Release](ctx, c, repoPath(owner, repo)+"/releases", nil, "")
+}
+
+// GetLatestRelease returns the newest release that is no draft or
+// prerelease.
+func (c *Client) GetLatestRelease(ctx context.Context, owner, repo string) (*Release, error) {
+	r := &Release{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/releases/latest", nil, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetReleaseByTag returns the release of a tag.
+func (c *Client) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*Release, error) {
+	r := &Release{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/releases/tags/"+escapePath(tag), nil, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetViews returns the views of a repository, it requires push access.
+func (c *Client) GetViews(ctx context.Context, owner, repo string) (*Traffic, error) {
+	views := struct {
+		Traffic
+		Views []TrafficDay `json:"views"`
+	}{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/traffic/views", nil, &views)
+	if err != nil {
+		return nil, err
+	}
+
+	views.Days = views.Views
+
+	return &views.Traffic, nil
+}
+
+// GetClones returns the clones of a repository, it requires push access.
+func (c *Client) GetClones(ctx context.Context, owner, repo string) (*Traffic, error) {
+	clones := struct {
+		Traffic
+		Clones []TrafficDay `json:"clones"`
+	}{}
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/traffic/clones", nil, &clones)
+	if err != nil {
+		return nil, err
+	}
+
+	clones.Days = clones.Clones
+
+	return &clones.Traffic, nil
+}
+
+// ListReferrers returns the top referrers of a repository, it requires push
+// access.
+func (c *Client) ListReferrers(ctx context.Context, owner, repo string) ([]Referrer, error) {
+	referrers := make([]Referrer, 0)
+
+	err := c.Get(ctx, repoPath(owner, repo)+"/traffic/popular/referrers", nil, &referrers)
+	if err != nil {
+		return nil, err
+	}
+
+	return referrers, nil
+}
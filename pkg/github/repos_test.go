@@ -0,0 +1,225 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRepositoryRequests(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want request
+
+		// body is the answer, when the endpoint answers with a list.
+		body string
+	}{
+		{
+			name: "create in the account of the token",
+			call: func(c *Client) error {
+				_, err := c.CreateRepository(ctx, "", &RepositoryRequest{Name: "hello-world"})
+				return err
+			},
+			want: request{method: "POST", path: "/user/repos", body: `{"name":"hello-world","private":false,"description":""}`},
+		},
+		{
+			name: "create in an organization",
+			call: func(c *Client) error {
+				_, err := c.CreateRepository(ctx, "github", &RepositoryRequest{Name: "hello-world", Private: true, LicenseTemplate: "mit"})
+				return err
+			},
+			want: request{method: "POST", path: "/orgs/github/repos", body: `{"name":"hello-world","private":true,"description":"","license_template":"mit"}`},
+		},
+		{
+			name: "fork",
+			call: func(c *Client) error {
+				_, err := c.CreateFork(ctx, "octocat", "hello-world", nil)
+				return err
+			},
+			want: request{method: "POST", path: "/repos/octocat/hello-world/forks", body: `{}`},
+		},
+		{
+			name: "fork into an organization",
+			call: func(c *Client) error {
+				_, err := c.CreateFork(ctx, "octocat", "hello-world", &ForkOptions{Organization: "github"})
+				return err
+			},
+			want: request{method: "POST", path: "/repos/octocat/hello-world/forks", body: `{"organization":"github"}`},
+		},
+		{
+			name: "delete",
+			call: func(c *Client) error {
+				return c.DeleteRepository(ctx, "octocat", "hello-world")
+			},
+			want: request{method: "DELETE", path: "/repos/octocat/hello-world"},
+		},
+		{
+			name: "star",
+			call: func(c *Client) error {
+				return c.Star(ctx, "octocat", "hello-world")
+			},
+			want: request{method: "PUT", path: "/user/starred/octocat/hello-world"},
+		},
+		{
+			name: "replace topics",
+			call: func(c *Client) error {
+				_, err := c.ReplaceTopics(ctx, "octocat", "hello-world", []string{"cli", "go"})
+				return err
+			},
+			want: request{method: "PUT", path: "/repos/octocat/hello-world/topics", body: `{"names":["cli","go"]}`},
+		},
+		{
+			name: "file at a ref",
+			call: func(c *Client) error {
+				_, err := c.GetFile(ctx, "octocat", "hello-world", "/docs/README.md", "v1.0.0")
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/contents/docs/README.md", query: "ref=v1.0.0"},
+		},
+		{
+			name: "file with a name to escape",
+			call: func(c *Client) error {
+				_, err := c.GetFile(ctx, "octocat", "hello-world", "docs/50% off #1?.md", "feature/a+b")
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/contents/docs/50%25%20off%20%231%3F.md", query: "ref=feature%2Fa%2Bb"},
+		},
+		{
+			name: "release of a tag to escape",
+			call: func(c *Client) error {
+				_, err := c.GetReleaseByTag(ctx, "octocat", "hello-world", "v1.0.0+build.1")
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/releases/tags/v1.0.0%2Bbuild.1"},
+		},
+		{
+			name: "branch to escape",
+			call: func(c *Client) error {
+				_, err := c.GetBranch(ctx, "octocat", "hello-world", "fix/#12")
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/branches/fix/%2312"},
+		},
+		{
+			name: "recursive tree",
+			call: func(c *Client) error {
+				_, err := c.GetTree(ctx, "octocat", "hello-world", "main", true)
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/git/trees/main", query: "recursive=1"},
+		},
+		{
+			name: "stargazers with the star dates",
+			call: func(c *Client) error {
+				_, err := c.ListStargazers(ctx, "octocat", "hello-world").Next()
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/stargazers", query: "per_page=100", accept: StarMediaType},
+			body: "[]",
+		},
+		{
+			name: "contributors with anonymous ones",
+			call: func(c *Client) error {
+				_, err := c.ListContributors(ctx, "octocat", "hello-world", true).Next()
+				return err
+			},
+			want: request{method: "GET", path: "/repos/octocat/hello-world/contributors", query: "anon=1&per_page=100"},
+			body: "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]request, 0)
+
+			if tt.body == "" {
+				tt.body = "{}"
+			}
+
+			err := tt.call(recordingClient(t, http.StatusOK, tt.body, &sent))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want.accept == "" {
+				tt.want.accept = "application/vnd.github+json"
+			}
+
+			if len(sent) != 1 || sent[0] != tt.want {
+				t.Errorf("sent = %+v, want %+v", sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFileDirectory(t *testing.T) {
+	sent := make([]request, 0)
+
+	_, err := recordingClient(t, http.StatusOK, `[{"name": "README.md", "type": "file"}]`, &sent).GetFile(context.Background(), "octocat", "hello-world", "docs", "")
+	if !errors.Is(err, ErrDirectory) {
+		t.Errorf("err = %v, want ErrDirectory", err)
+	}
+	if sent[0].query != "" {
+		t.Errorf("query = %q, want none without a ref", sent[0].query)
+	}
+}
+
+func TestGetTopics(t *testing.T) {
+	sent := make([]request, 0)
+
+	topics, err := recordingClient(t, http.StatusOK, `{"names": ["cli", "go"]}`, &sent).GetTopics(context.Background(), "octocat", "hello-world")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(topics, []string{"cli", "go"}) || sent[0].path != "/repos/octocat/hello-world/topics" {
+		t.Errorf("topics = %v from %s", topics, sent[0].path)
+	}
+}
+
+func TestCollaboratorPermission(t *testing.T) {
+	tests := []struct {
+		role        string
+		permissions CollaboratorPermissions
+		want        string
+	}{
+		{role: "security-manager", permissions: CollaboratorPermissions{Pull: true}, want: "security-manager"},
+		{permissions: CollaboratorPermissions{Admin: true, Maintain: true, Push: true, Triage: true, Pull: true}, want: "admin"},
+		{permissions: CollaboratorPermissions{Push: true, Triage: true, Pull: true}, want: "write"},
+		{permissions: CollaboratorPermissions{Pull: true}, want: "read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			c := Collaborator{RoleName: tt.role, Permissions: tt.permissions}
+
+			if got := c.Permission(); got != tt.want {
+				t.Errorf("permission = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListReleasesPages(t *testing.T) {
+	sent := make([]*http.Request, 0)
+
+	releases, err := pagedClient(t, 250, &sent).ListReleases(context.Background(), "octocat", "hello-world").All(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(releases) != 250 || len(sent) != 3 {
+		t.Errorf("releases = %d in %d requests, want 250 in 3", len(releases), len(sent))
+	}
+
+	for _, req := range sent {
+		if req.URL.Path != "/repos/octocat/hello-world/releases" {
+			t.Errorf("path = %s", req.URL.Path)
+		}
+	}
+}
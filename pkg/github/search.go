@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Repository is a repository as github lists it in searches and lists of
+// users and organizations.
+type Repository struct {
+	FullName        string    `json:"full_name"`
+	Description     string    `json:"description"`
+	Language        string    `json:"language"`
+	Topics          []string  `json:"topics"`
+	StargazersCount int       `json:"stargazers_count"`
+	ForksCount      int       `json:"forks_count"`
+	Fork            bool      `json:"fork"`
+	Private         bool      `json:"private"`
+	Archived        bool      `json:"archived"`
+	HTMLURL         string    `json:"html_url"`
+	DefaultBranch   string    `json:"default_branch"`
+	PushedAt        time.Time `json:"pushed_at"`
+}
+
+// User is a user as github lists it in searches and lists of followers.
+type User struct {
+	Login   string `json:"login"`
+	HTMLURL string `json:"html_url"`
+}
+
+// SearchOptions are the options of a search, the zero value gives the
+// first 30 results by best match.
+type SearchOptions struct {
+	// Sort is the field to sort by, e.g. stars or updated for
+	// repositories and followers or joined for users.
+	Sort string
+
+	// Order is asc or desc, the default, with Sort.
+	Order string
+
+	// PerPage is the number of results of a page, at most 100.
+	PerPage int
+
+	// Page is the page of results, starting at 1.
+	Page int
+}
+
+func (o *SearchOptions) query(q string) url.Values {
+	query := url.Values{}
+	query.Set("q", q)
+
+	if o == nil {
+		return query
+	}
+
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if o.Order != "" {
+		query.Set("order", o.Order)
+	}
+	if o.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+
+	return query
+}
+
+// SearchResult is a page of results of a search.
+type SearchResult[T any] struct {
+	TotalCount        int  `json:"total_count"`
+	IncompleteResults bool `json:"incomplete_results"`
+	Items             []T  `json:"items"`
+
+	// Skipped is the number of results left out because they did not
+	// decode, like a field of another type than documented, instead of
+	// failing the whole search.
+	Skipped int `json:"-"`
+}
+
+// SearchRepositories searches repositories with github search syntax, e.g.
+// "topic:cli language:go".
+func (c *Client) SearchRepositories(ctx context.Context, q string, opts *SearchOptions) (*SearchResult[Repository], error) {
+	return search[Repository](ctx, c, "/search/repositories", q, opts)
+}
+
+// SearchUsers searches users with github search syntax, e.g.
+// "location:berlin followers:>100".
+func (c *Client) SearchUsers(ctx context.Context, q string, opts *SearchOptions) (*SearchResult[User], error) {
+	return search[User](ctx, c, "/search/users", q, opts)
+}
+
+func search[T any](ctx context.Context, c *Client, path, q string, opts *SearchOptions) (*SearchResult[T], error) {
+	page := struct {
+		TotalCount        int               `json:"total_count"`
+		IncompleteResults bool              `json:"incomplete_results"`
+		Items             []json.RawMessage `json:"items"`
+	}{}
+
+	err := c.Get(ctx, path, opts.query(q), &page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult[T]{TotalCount: page.TotalCount, IncompleteResults: page.IncompleteResults, Items: make([]T, 0, len(page.Items))}
+
+	for _, raw := range page.Items {
+		var item T
+
+		if json.Unmarshal(raw, &item) != nil {
+			result.Skipped++
+			continue
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
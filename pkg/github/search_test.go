@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc is a fake api, the transport of the clients of the tests.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// respond answers with status and a body, the file of testdata if body names
+// one.
+func respond(t *testing.T, req *http.Request, status int, body string) (*http.Response, error) {
+	t.Helper()
+
+	if strings.HasSuffix(body, ".json") {
+		data, err := os.ReadFile(filepath.Join("testdata", body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(data)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// fakeClient returns a client answering every request with the file of
+// testdata, the requests are collected in sent.
+func fakeClient(t *testing.T, file string, sent *[]*http.Request) *Client {
+	return NewClient(Options{
+		Token: "test-token",
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			*sent = append(*sent, req)
+			return respond(t, req, http.StatusOK, file)
+		}),
+	})
+}
+
+func TestSearchRepositories(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		names   []string
+		skipped int
+	}{
+		{name: "recorded", file: "search_repositories.json", names: []string{"spf13/cobra", "urfave/cli"}},
+		{name: "wrong types", file: "search_repositories_invalid.json", names: []string{"spf13/cobra"}, skipped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]*http.Request, 0)
+			client := fakeClient(t, tt.file, &sent)
+
+			result, err := client.SearchRepositories(context.Background(), "language:go topic:cli", &SearchOptions{Sort: "stars", PerPage: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0)
+			for _, r := range result.Items {
+				names = append(names, r.FullName)
+			}
+
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("names = %v, want %v", names, tt.names)
+			}
+			if result.Skipped != tt.skipped {
+				t.Errorf("skipped = %d, want %d", result.Skipped, tt.skipped)
+			}
+
+			req := sent[0]
+			if req.URL.Path != "/search/repositories" {
+				t.Errorf("path = %s, want /search/repositories", req.URL.Path)
+			}
+			if q := req.URL.Query(); q.Get("q") != "language:go topic:cli" || q.Get("sort") != "stars" || q.Get("per_page") != "2" || q.Has("page") {
+				t.Errorf("query = %s", req.URL.RawQuery)
+			}
+			if auth := req.Header.Get("Authorization"); auth != "Bearer test-token" {
+				t.Errorf("authorization = %q", auth)
+			}
+		})
+	}
+}
+
+func TestSearchRepositoriesFields(t *testing.T) {
+	sent := make([]*http.Request, 0)
+
+	result, err := fakeClient(t, "search_repositories.json", &sent).SearchRepositories(context.Background(), "cli", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.TotalCount != 2 || result.IncompleteResults {
+		t.Errorf("total = %d, incomplete = %t", result.TotalCount, result.IncompleteResults)
+	}
+
+	cobra := result.Items[0]
+	if cobra.Description != "A Commander for modern Go CLI interactions" || cobra.Language != "Go" || cobra.StargazersCount != 39012 ||
+		cobra.ForksCount != 2854 || cobra.DefaultBranch != "main" || cobra.PushedAt.IsZero() || len(cobra.Topics) != 6 {
+		t.Errorf("cobra = %+v", cobra)
+	}
+
+	// Null fields are left empty.
+	cli := result.Items[1]
+	if cli.Description != "" || cli.Language != "" || cli.StargazersCount != 22610 {
+		t.Errorf("cli = %+v", cli)
+	}
+
+	if q := sent[0].URL.Query(); len(q) != 1 || q.Get("q") != "cli" {
+		t.Errorf("query = %s, want only q", sent[0].URL.RawQuery)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		logins  []string
+		urls    []string
+		skipped int
+	}{
+		{name: "recorded", file: "search_users.json", logins: []string{"octocat", "github"}, urls: []string{"https://github.com/octocat", "https://github.com/github"}},
+		{name: "missing fields and wrong types", file: "search_users_invalid.json", logins: []string{"octocat", "github"}, urls: []string{"", "https://github.com/github"}, skipped: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := make([]*http.Request, 0)
+			client := fakeClient(t, tt.file, &sent)
+
+			result, err := client.SearchUsers(context.Background(), "location:berlin", &SearchOptions{Sort: "followers", Order: "asc", Page: 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			logins := make([]string, 0)
+			urls := make([]string, 0)
+			for _, u := range result.Items {
+				logins = append(logins, u.Login)
+				urls = append(urls, u.HTMLURL)
+			}
+
+			if !reflect.DeepEqual(logins, tt.logins) {
+				t.Errorf("logins = %v, want %v", logins, tt.logins)
+			}
+			if !reflect.DeepEqual(urls, tt.urls) {
+				t.Errorf("urls = %v, want %v", urls, tt.urls)
+			}
+			if result.Skipped != tt.skipped {
+				t.Errorf("skipped = %d, want %d", result.Skipped, tt.skipped)
+			}
+
+			req := sent[0]
+			if req.URL.Path != "/search/users" {
+				t.Errorf("path = %s, want /search/users", req.URL.Path)
+			}
+			if q := req.URL.Query(); q.Get("sort") != "followers" || q.Get("order") != "asc" || q.Get("page") != "3" {
+				t.Errorf("query = %s", req.URL.RawQuery)
+			}
+		})
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+	}{
+		{name: "validation", status: http.StatusUnprocessableEntity, body: `{"message":"Validation Failed","errors":[{"resource":"Search","field":"q","code":"missing"}],"documentation_url":"https://docs.github.com/v3/search"}`, message: "Validation Failed"},
+		{name: "not json", status: http.StatusBadGateway, body: "<html>bad gateway</html>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return respond(t, req, tt.status, tt.body)
+			})})
+
+			_, err := client.SearchRepositories(context.Background(), "", nil)
+
+			e, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("err = %v, want an *Error", err)
+			}
+			if e.Response.StatusCode != tt.status || e.Message != tt.message {
+				t.Errorf("status = %d, message = %q", e.Response.StatusCode, e.Message)
+			}
+		})
+	}
+}
+
+func TestSearchInvalidResponse(t *testing.T) {
+	client := NewClient(Options{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return respond(t, req, http.StatusOK, `{"total_count": "many", "items": []}`)
+	})})
+
+	_, err := client.SearchUsers(context.Background(), "octocat", nil)
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("err = %v, want ErrInvalidResponse", err)
+	}
+}
@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// UserProfile is the profile of a user.
+type UserProfile struct {
+	Login       string    `json:"login"`
+	Name        string    `json:"name"`
+	Bio         string    `json:"bio"`
+	Company     string    `json:"company"`
+	Location    string    `json:"location"`
+	Followers   int       `json:"followers"`
+	Following   int       `json:"following"`
+	PublicRepos int       `json:"public_repos"`
+	HTMLURL     string    `json:"html_url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SponsorTier is a tier of a sponsors listing.
+type SponsorTier struct {
+	Name                  string `json:"name"`
+	MonthlyPriceInDollars int    `json:"monthlyPriceInDollars"`
+	IsOneTime             bool   `json:"isOneTime"`
+}
+
+// Sponsorship is whether a user can be sponsored, by how many sponsors and
+// with which tiers.
+type Sponsorship struct {
+	Login       string
+	Sponsorable bool
+	Sponsors    int
+	Tiers       []SponsorTier
+}
+
+// GetUser returns the profile of a user.
+func (c *Client) GetUser(ctx context.Context, login string) (*UserProfile, error) {
+	return c.getUser(ctx, "/users/"+login)
+}
+
+// GetAuthenticatedUser returns the profile of the user of the token.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*UserProfile, error) {
+	return c.getUser(ctx, "/user")
+}
+
+func (c *Client) getUser(ctx context.Context, path string) (*UserProfile, error) {
+	u := &UserProfile{}
+
+	err := c.Get(ctx, path, nil, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// ListUserRepositories lists the repositories a user owns, sorted by
+// created, updated, pushed or full_name.
+func (c *Client) ListUserRepositories(ctx context.Context, login, sort string) *Pages[Repository] {
+	query := url.Values{}
+	query.Set("type", "owner")
+	query.Set("sort", sort)
+
+	return listPages[Repository](ctx, c, fmt.Sprintf("/users/%s/repos", login), query, "")
+}
+
+// ListFollowers lists the users following a user.
+func (c *Client) ListFollowers(ctx context.Context, login string) *Pages[User] {
+	return listPages[User](ctx, c, fmt.Sprintf("/users/%s/followers", login), nil, "")
+}
+
+// ListFollowing lists the users a user follows.
+func (c *Client) ListFollowing(ctx context.Context, login string) *Pages[User] {
+	return listPages[User](ctx, c, fmt.Sprintf("/users/%s/following", login), nil, "")
+}
+
+// ListStarred lists the repositories a user starred, those of the user of
+// the token when login is empty, sorted by when they were starred (created)
+// or last pushed to (updated).
+func (c *Client) ListStarred(ctx context.Context, login, sort string) *Pages[StarredRepository] {
+	path := "/user/starred"
+	if login != "" {
+		path = fmt.Sprintf("/users/%s/starred", login)
+	}
+
+	query := url.Values{}
+	query.Set("sort", sort)
+
+	pages := listPages[StarredRepository](ctx, c, path, query, "")
+	if pages.req != nil {
+		pages.req.Header.Set("Accept", StarMediaType)
+	}
+
+	return pages
+}
+
+// Follow follows a user with the user of the token.
+func (c *Client) Follow(ctx context.Context, login string) error {
+	return c.send(ctx, http.MethodPut, "/user/following/"+login, nil, nil)
+}
+
+// Unfollow stops following a user with the user of the token.
+func (c *Client) Unfollow(ctx context.Context, login string) error {
+	return c.send(ctx, http.MethodDelete, "/user/following/"+login, nil, nil)
+}
+
+const sponsorsQuery = `query($login: String!) {
+  user(login: $login) {
+    login
+    hasSponsorsListing
+    sponsors {
+      totalCount
+    }
+    sponsorsListing {
+      tiers(first: 20) {
+        nodes {
+          name
+          monthlyPriceInDollars
+          isOneTime
+        }
+      }
+    }
+  }
+}`
+
+// GetSponsorship returns the sponsors listing of a user with the graphql
+// api.
+func (c *Client) GetSponsorship(ctx context.Context, login string) (*Sponsorship, error) {
+	result := struct {
+		User *struct {
+			Login              string `json:"login"`
+			HasSponsorsListing bool   `json:"hasSponsorsListing"`
+			Sponsors           struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"sponsors"`
+			SponsorsListing *struct {
+				Tiers struct {
+					Nodes []SponsorTier `json:"nodes"`
+				} `json:"tiers"`
+			} `json:"sponsorsListing"`
+		} `json:"user"`
+	}{}
+
+	err := c.GraphQL(ctx, sponsorsQuery, map[string]interface{}{"login": login}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.User == nil {
+		return nil, notFound("could not resolve to a user with the login of '%s'", login)
+	}
+
+	s := &Sponsorship{
+		Login:       result.User.Login,
+		Sponsorable: result.User.HasSponsorsListing,
+		Sponsors:    result.User.Sponsors.TotalCount,
+		Tiers:       make([]SponsorTier, 0),
+	}
+
+	if result.User.SponsorsListing != nil {
+		s.Tiers = append(s.Tiers, result.User.SponsorsListing.Tiers.Nodes...)
+	}
+
+	return s, nil
+}
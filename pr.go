@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var prUsage = `Specify a pr command to execute:
+  - list: List the pull requests of a repository
+  - checkout: Check out a pull request locally`
+
+func executePR(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(prUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("pr", "command", command)
+
+	switch command {
+	case "list":
+		return executePRList(ctx, args[1:])
+	case "checkout":
+		return executePRCheckout(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid pr command: '%s'", command))
+	}
+}
+
+type pullRequest = github.PullRequest
+
+// reviewStatus describes the review decision in lower case, e.g. approved.
+func reviewStatus(pr pullRequest) string {
+	if pr.ReviewDecision == "" {
+		return "-"
+	}
+
+	return strings.ToLower(strings.ReplaceAll(pr.ReviewDecision, "_", " "))
+}
+
+// ciStatus describes the combined state of the checks of the latest
+// commit in lower case.
+func ciStatus(pr pullRequest) string {
+	if pr.CIState() == "" {
+		return "-"
+	}
+
+	return strings.ToLower(pr.CIState())
+}
+
+// pullRequestsWebURL builds the url of the pull requests page of a repository
+// searching for the same pull requests as pr list.
+func pullRequestsWebURL(owner, name, state string, draft bool, labels []string, reviewer string) string {
+	terms := []string{"is:pr"}
+
+	if state != "all" {
+		terms = append(terms, "is:"+state)
+	}
+
+	if draft {
+		terms = append(terms, "draft:true")
+	}
+
+	for _, l := range labels {
+		terms = append(terms, fmt.Sprintf("label:%q", l))
+	}
+
+	if reviewer != "" {
+		terms = append(terms, "review-requested:"+reviewer)
+	}
+
+	query := url.Values{}
+	query.Set("q", strings.Join(terms, " "))
+
+	return fmt.Sprintf("https://github.com/%s/%s/pulls?%s", owner, name, query.Encode())
+}
+
+func executePRList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("pr list", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository to list the pull requests of, owner/name")
+	state := flagSet.String("state", "open", "only show pull requests in a state: open, closed, merged or all")
+	draft := flagSet.Bool("draft", false, "only show draft pull requests")
+	reviewer := flagSet.String("reviewer", "", "only show pull requests a review was requested from a user or team, @me for yourself")
+	labels := stringList{}
+	flagSet.Var(&labels, "label", "only show pull requests with a label, can be repeated")
+	web := flagSet.Bool("web", false, "open the filtered pull requests in the browser")
+	page := addPageFlags(flagSet)
+
+	parseArgs(flagSet, args)
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil {
+		return usageError("provide a repository: pr list --repo <owner/name>")
+	}
+
+	states := map[string][]string{
+		"open":   {"OPEN"},
+		"closed": {"CLOSED"},
+		"merged": {"MERGED"},
+		"all":    {"OPEN", "CLOSED", "MERGED"},
+	}[*state]
+
+	if states == nil {
+		return usageErrorf("invalid state '%s', expected open, closed, merged or all", *state)
+	}
+
+	// The web search understands @me, so it is not resolved first.
+	if *web {
+		return openBrowser(ctx, pullRequestsWebURL(owner, name, *state, *draft, labels, *reviewer))
+	}
+
+	if *reviewer == "@me" {
+		*reviewer, err = findAuthenticatedLogin(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	loggerFrom(ctx).Debug("pr list", "repo", owner+"/"+name, "state", *state, "draft", *draft, "labels", labels, "reviewer", *reviewer)
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	client := githubClient()
+	opts := &github.PullRequestOptions{States: states, Labels: labels}
+
+	prs := make([]pullRequest, 0)
+	limit := page.max()
+	after := ""
+
+	// Draft and reviewer are filtered while paginating, graphql has no
+	// arguments for them.
+	for {
+		nodes, pageInfo, err := client.ListPullRequests(ctx, owner, name, opts, after)
+		if err != nil {
+			return requestError(ctx, err)
+		}
+
+		for _, pr := range nodes {
+			if *draft && !pr.IsDraft || *reviewer != "" && !pr.RequestedFrom(*reviewer) {
+				continue
+			}
+
+			prs = append(prs, pr)
+		}
+
+		if limit > 0 && len(prs) >= limit {
+			prs = prs[:limit]
+			break
+		}
+
+		if !pageInfo.HasNextPage {
+			break
+		}
+
+		after = pageInfo.EndCursor
+	}
+
+	if jsonOutput() {
+		return printJSON(prs)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NUMBER\tTITLE\tAUTHOR\tBRANCH\tREVIEW\tCI")
+
+	for _, pr := range prs {
+		title := truncate(pr.Title, 50)
+		if pr.IsDraft {
+			title = "[draft] " + title
+		}
+
+		fmt.Fprintf(w, "#%d\t%s\t%s\t%s <- %s\t%s\t%s\n", pr.Number, title, pr.Author.Login, pr.BaseRefName, pr.HeadRefName, reviewStatus(pr), ciStatus(pr))
+	}
+
+	return w.Flush()
+}
+
+func executePRCheckout(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("pr checkout", flag.ExitOnError)
+
+	repo := flagSet.String("repo", "", "repository of the pull request, owner/name")
+	branchName := flagSet.String("branch", "", "local branch to check out the pull request into, defaults to its head branch or pr-<number> for forks")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Pull request number")
+
+	if len(args) == 0 {
+		return usageError("provide a pull request: pr checkout <number> --repo <owner/name>")
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil {
+		return usageErrorf("invalid pull request number '%s'", args[0])
+	}
+
+	owner, name, err := parseRepoName(*repo)
+	if err != nil {
+		return usageError("provide a repository: pr checkout <number> --repo <owner/name>")
+	}
+
+	loggerFrom(ctx).Debug("pr checkout", "repo", owner+"/"+name, "number", number)
+
+	head, err := githubClient().GetPullRequestHead(ctx, owner, name, number)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	branch := *branchName
+	if branch == "" {
+		branch = pullRequestBranch(ctx, head, number)
+	}
+
+	// The pull request ref of the base repository works for pull requests
+	// from forks too, no remote for the fork is needed.
+	remote := fmt.Sprintf("https://github.com/%s/%s.git", owner, name)
+	ref := fmt.Sprintf("refs/pull/%d/head", number)
+
+	current, _ := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+
+	// The checked out branch is never moved to the pull request.
+	if strings.TrimSpace(string(current)) == branch {
+		return fmt.Errorf("%s is checked out, switch to another branch to check out the pull request into it", branch)
+	}
+
+	err = runGit(ctx, "fetch", remote, ref+":"+branch)
+	if err != nil {
+		return err
+	}
+
+	return runGit(ctx, "checkout", branch)
+}
+
+// pullRequestBranch returns the local branch to check out a pull request
+// into, its head branch unless that is of a fork, often main, or a local
+// branch of that name already exists, which both are not the branch of the
+// pull request. Those get pr-<number> instead.
+func pullRequestBranch(ctx context.Context, head *github.PullRequestHead, number int) string {
+	if head.Fork || runGitQuiet(ctx, "rev-parse", "--verify", "--quiet", "refs/heads/"+head.Ref) == nil {
+		return fmt.Sprintf("pr-%d", number)
+	}
+
+	return head.Ref
+}
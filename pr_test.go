@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// inGitRepository runs the test in a new git repository with a commit on
+// main and the branches.
+func inGitRepository(t *testing.T, branches ...string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+
+	commands := [][]string{
+		{"init", "--quiet", "--initial-branch", "main"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "initial"},
+	}
+	for _, branch := range branches {
+		commands = append(commands, []string{"branch", branch})
+	}
+
+	for _, args := range commands {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+}
+
+func TestPullRequestBranch(t *testing.T) {
+	inGitRepository(t, "taken")
+
+	tests := []struct {
+		name string
+		head github.PullRequestHead
+		want string
+	}{
+		{name: "branch of the repository", head: github.PullRequestHead{Ref: "fix-typo", Repository: "octocat/hello-world"}, want: "fix-typo"},
+		{name: "main of a fork", head: github.PullRequestHead{Ref: "main", Repository: "hubot/hello-world", Fork: true}, want: "pr-7"},
+		{name: "branch of a fork", head: github.PullRequestHead{Ref: "fix-typo", Repository: "hubot/hello-world", Fork: true}, want: "pr-7"},
+		{name: "name taken by a local branch", head: github.PullRequestHead{Ref: "taken", Repository: "octocat/hello-world"}, want: "pr-7"},
+		{name: "name of the checked out branch", head: github.PullRequestHead{Ref: "main", Repository: "octocat/hello-world"}, want: "pr-7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pullRequestBranch(context.Background(), &tt.head, 7); got != tt.want {
+				t.Errorf("pullRequestBranch = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+const progressBarWidth = 30
+
+// progressBar renders the progress of a transfer on stderr. It implements
+// io.Writer so it can be used with io.TeeReader or io.MultiWriter, every
+// written byte counts towards the progress. Like the spinners it is only
+// drawn on a terminal.
+type progressBar struct {
+	enabled  bool
+	label    string
+	total    int64
+	current  int64
+	lastDraw time.Time
+}
+
+// newProgressBar creates a progress bar for a transfer of total bytes that
+// already has current bytes done, e.g. when resuming a download. A total of
+// zero or less means the size of the transfer is unknown.
+func newProgressBar(label string, total, current int64) *progressBar {
+	return &progressBar{
+		enabled: showActivity(),
+		label:   label,
+		total:   total,
+		current: current,
+	}
+}
+
+func (p *progressBar) Write(b []byte) (int, error) {
+	p.current += int64(len(b))
+
+	// Redrawing on every write makes the terminal flicker.
+	if time.Since(p.lastDraw) > 100*time.Millisecond {
+		p.draw()
+	}
+
+	return len(b), nil
+}
+
+// Finish draws the final state of the bar and moves to the next line.
+func (p *progressBar) Finish() {
+	if !p.enabled {
+		return
+	}
+
+	p.draw()
+	fmt.Fprintln(os.Stderr)
+}
+
+func (p *progressBar) draw() {
+	p.lastDraw = time.Now()
+
+	if !p.enabled {
+		return
+	}
+
+	if p.total <= 0 {
+		fmt.Fprintf(os.Stderr, "\r%s %s", p.label, formatBytes(p.current))
+		return
+	}
+
+	filled := int(float64(progressBarWidth) * float64(p.current) / float64(p.total))
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	percent := 100 * float64(p.current) / float64(p.total)
+
+	fmt.Fprintf(os.Stderr, "\r%s [%s] %3.0f%% %s/%s", p.label, bar, percent, formatBytes(p.current), formatBytes(p.total))
+}
+
+// formatBytes formats n bytes in a human readable form, e.g. 1.5MB.
+func formatBytes(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// pageProgress shows the progress of a fetch of many pages on stderr: the
+// pages fetched, the results collected and the remaining rate limit. It
+// only appears once a second page is needed and replaces the request
+// spinners while it is shown.
+type pageProgress struct {
+	enabled bool
+	paused  bool
+	page    int
+	total   int
+}
+
+func newPageProgress() *pageProgress {
+	return &pageProgress{enabled: showActivity()}
+}
+
+// update records a fetched page that is followed by more pages. Limit is the number of results wanted and
+// perPage the page size, they bound the pages fetched when the total number
+// of pages is larger. A limit of zero fetches every page.
+func (p *pageProgress) update(header http.Header, results, limit, perPage int) {
+	p.page++
+
+	if last := github.LastPage(header); last > 0 {
+		p.total = last
+
+		if limit > 0 && perPage > 0 {
+			if wanted := int(math.Ceil(float64(limit) / float64(perPage))); wanted < p.total {
+				p.total = wanted
+			}
+		}
+	}
+
+	if !p.enabled {
+		return
+	}
+
+	if !p.paused {
+		p.paused = true
+		spinnersPaused = true
+	}
+
+	status := fmt.Sprintf("page %d", p.page)
+
+	if p.total > 0 {
+		filled := progressBarWidth * p.page / p.total
+		bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+		status = fmt.Sprintf("[%s] page %d/%d", bar, p.page, p.total)
+	}
+
+	status += fmt.Sprintf(", %d results", results)
+
+	if remaining := header.Get("X-RateLimit-Remaining"); remaining != "" {
+		status += fmt.Sprintf(", %s requests left", remaining)
+	}
+
+	fmt.Fprintf(os.Stderr, "\r\033[K%s", status)
+}
+
+// Finish clears the status line before the results are printed.
+func (p *pageProgress) Finish() {
+	if !p.paused {
+		return
+	}
+
+	fmt.Fprint(os.Stderr, "\r\033[K")
+	spinnersPaused = false
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{n: 0, want: "0B"},
+		{n: 1023, want: "1023B"},
+		{n: 1536, want: "1.5KB"},
+		{n: 300000, want: "293.0KB"},
+		{n: 5 << 30, want: "5.0GB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %s, want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestProgressBarNotOnTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() {
+		os.Stderr = stderr
+	}()
+
+	progress := newProgressBar("asset.tar.gz", 10, 0)
+	io.Copy(progress, strings.NewReader("0123456789"))
+	progress.Finish()
+
+	os.Stderr = stderr
+	w.Close()
+
+	out, _ := io.ReadAll(r)
+	if len(out) > 0 {
+		t.Errorf("progress drawn on a pipe: %q", out)
+	}
+	if progress.current != 10 {
+		t.Errorf("current = %d, want 10", progress.current)
+	}
+}
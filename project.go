@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var projectUsage = `Specify a project command to execute:
+  - list: List the projects of a user or organization
+  - items: List the items of a project`
+
+func executeProject(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(projectUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("project", "command", command)
+
+	switch command {
+	case "list":
+		return executeProjectList(ctx, args[1:])
+	case "items":
+		return executeProjectItems(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid project command: '%s'", command))
+	}
+}
+
+type project = github.Project
+
+func executeProjectList(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("project list", flag.ExitOnError)
+
+	owner := flagSet.String("owner", "", "user or organization owning the projects")
+	closed := flagSet.Bool("closed", false, "include closed projects")
+	page := addPageFlags(flagSet)
+
+	parseArgs(flagSet, args)
+
+	if *owner == "" {
+		return usageError("provide an owner: project list --owner <login>")
+	}
+
+	loggerFrom(ctx).Debug("project list", "owner", *owner, "closed", *closed)
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	client := githubClient()
+
+	projects := make([]project, 0)
+	limit := page.max()
+	after := ""
+
+	for {
+		nodes, pageInfo, err := client.ListProjects(ctx, *owner, after)
+		if err != nil {
+			return requestError(ctx, err)
+		}
+
+		for _, p := range nodes {
+			if p.Closed && !*closed {
+				continue
+			}
+
+			projects = append(projects, p)
+		}
+
+		if limit > 0 && len(projects) >= limit {
+			projects = projects[:limit]
+			break
+		}
+
+		if !pageInfo.HasNextPage {
+			break
+		}
+
+		after = pageInfo.EndCursor
+	}
+
+	if jsonOutput() {
+		return printJSON(projects)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NUMBER\tTITLE\tITEMS\tSTATE\tDESCRIPTION")
+
+	for _, p := range projects {
+		state := "open"
+		if p.Closed {
+			state = "closed"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n", p.Number, p.Title, p.Items.TotalCount, state, truncate(p.ShortDescription, 50))
+	}
+
+	return w.Flush()
+}
+
+type projectItem = github.ProjectItem
+
+func itemReference(i projectItem) string {
+	if i.Content.Number == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s#%d", i.Content.Repository.NameWithOwner, i.Content.Number)
+}
+
+func executeProjectItems(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("project items", flag.ExitOnError)
+
+	owner := flagSet.String("owner", "", "user or organization owning the project")
+	fieldNames := stringList{}
+	flagSet.Var(&fieldNames, "field", "field to show as a column, can be repeated, defaults to all fields")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+
+	if *owner == "" || len(args) == 0 {
+		return usageError("provide an owner and project: project items <number> --owner <login>")
+	}
+
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return usageErrorf("invalid project number '%s'", args[0])
+	}
+
+	loggerFrom(ctx).Debug("project items", "owner", *owner, "number", number, "fields", fieldNames)
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	client := githubClient()
+
+	items := make([]projectItem, 0)
+	limit := page.max()
+	after := ""
+
+	for {
+		nodes, pageInfo, err := client.ListProjectItems(ctx, *owner, number, after)
+		if err != nil {
+			return requestError(ctx, err)
+		}
+
+		items = append(items, nodes...)
+
+		if limit > 0 && len(items) >= limit {
+			items = items[:limit]
+			break
+		}
+
+		if !pageInfo.HasNextPage {
+			break
+		}
+
+		after = pageInfo.EndCursor
+	}
+
+	if jsonOutput() {
+		return printJSON(items)
+	}
+
+	// Without --field every field that has a value on any item becomes a
+	// column, in the order they are first seen.
+	columns := []string(fieldNames)
+	if len(columns) == 0 {
+		seen := make(map[string]bool)
+
+		for _, item := range items {
+			for _, v := range item.FieldValues.Nodes {
+				name := v.Field.Name
+				if name == "" || name == "Title" || seen[name] {
+					continue
+				}
+
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	header := []string{"TYPE", "TITLE", "REFERENCE"}
+	for _, c := range columns {
+		header = append(header, strings.ToUpper(c))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, item := range items {
+		fields := item.Fields()
+
+		row := []string{strings.ToLower(strings.ReplaceAll(item.Type, "_", " ")), truncate(item.Content.Title, 50), itemReference(item)}
+		for _, c := range columns {
+			value := fields[c]
+			if value == "" {
+				value = "-"
+			}
+			row = append(row, value)
+		}
+
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// canPrompt reports whether the user can be asked for input, stdin has to
+// be a terminal and prompts must not be turned off with -no-input.
+func canPrompt() bool {
+	return !*noInput && isTerminal(os.Stdin)
+}
+
+// prompt asks for a line of input on stderr and returns it trimmed.
+func prompt(label string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// promptMissing asks for the first positional argument when args is empty
+// and prompting is possible. Args is returned as is otherwise, so callers
+// keep their usage error for scripts.
+func promptMissing(args []string, label string) []string {
+	if len(args) > 0 || !canPrompt() {
+		return args
+	}
+
+	answer, err := prompt(label)
+	if err != nil || answer == "" {
+		return args
+	}
+
+	return []string{answer}
+}
+
+// confirm asks the user to confirm a destructive or bulk action. Yes, from
+// a --yes flag, skips the question. Without a terminal to ask on the action
+// is refused, scripts have to pass --yes.
+func confirm(question string, yes bool) error {
+	if yes {
+		return nil
+	}
+
+	if !canPrompt() {
+		return errors.New("refusing to continue without confirmation, pass --yes to confirm")
+	}
+
+	answer, err := prompt(question + " [y/N]")
+	if err != nil {
+		return err
+	}
+
+	answer = strings.ToLower(answer)
+	if answer != "y" && answer != "yes" {
+		return errors.New("cancelled")
+	}
+
+	return nil
+}
+
+// confirmTyped is like confirm but makes the user type expected, e.g. the
+// name of a repository, for actions that can not be undone.
+func confirmTyped(question, expected string, yes bool) error {
+	if yes {
+		return nil
+	}
+
+	if !canPrompt() {
+		return errors.New("refusing to continue without confirmation, pass --yes to confirm")
+	}
+
+	answer, err := prompt(question)
+	if err != nil {
+		return err
+	}
+
+	if answer != expected {
+		return fmt.Errorf("'%s' did not match, cancelled", answer)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// secretEnv are the environment variables holding secrets, their values are
+// masked wherever they show up in diagnostics.
+var secretEnv = []string{"GITHUB_TOKEN", "GH_TOKEN", "GCF_WEBHOOK_SECRET", "GCF_SERVE_KEY", "GCF_ALERT_URL"}
+
+// secretPatterns match secrets by their shape: authorization headers,
+// github tokens, credentials in urls and secret query parameters.
+var secretPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)((?:proxy-)?authorization["']?\s*[:=]\s*["']?(?:bearer |token |basic )?)[^\s"',]+`), "${1}***"},
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})`), "***"},
+	{regexp.MustCompile(`(://)[^/\s:@"]+(?::[^/\s@"]*)?@`), "${1}***@"},
+	{regexp.MustCompile(`(?i)([?&](?:access_token|token|client_secret|secret|api_key|key|sig|signature|x-amz-signature|x-amz-credential|x-amz-security-token|jwt|code|password)=)[^&\s"]+`), "${1}***"},
+}
+
+var knownSecrets struct {
+	once   sync.Once
+	values []string
+}
+
+// secretValues returns the secrets of this invocation: the values of
+// secretEnv, of the flags in secretFlags, of the OTLP headers and the
+// "secrets" of the config. Longest first, so no part of one is left over
+// when another is a part of it.
+func secretValues() []string {
+	knownSecrets.once.Do(func() {
+		values := make([]string, 0)
+
+		for _, name := range secretEnv {
+			values = append(values, os.Getenv(name))
+		}
+
+		values = append(values, secretArgs(os.Args[1:])...)
+
+		for _, value := range parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")) {
+			values = append(values, value)
+		}
+
+		// The config can only be read without logging, while the logger is
+		// not in use.
+		if cfg, err := readConfig(); err == nil {
+			values = append(values, cfg.Secrets...)
+		}
+
+		for _, v := range values {
+			// Short values would mask unrelated text.
+			if len(v) >= 4 {
+				knownSecrets.values = append(knownSecrets.values, v)
+			}
+		}
+
+		sort.Slice(knownSecrets.values, func(i, j int) bool {
+			return len(knownSecrets.values[i]) > len(knownSecrets.values[j])
+		})
+	})
+
+	return knownSecrets.values
+}
+
+// secretArgs returns the values of the secretFlags in args.
+func secretArgs(args []string) []string {
+	values := make([]string, 0)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		if name, value, ok := strings.Cut(arg, "="); ok {
+			if secretFlag(name) {
+				values = append(values, value)
+			}
+		} else if secretFlag(arg) && i+1 < len(args) {
+			i++
+			values = append(values, args[i])
+		}
+	}
+
+	return values
+}
+
+func secretFlag(name string) bool {
+	name = strings.ToLower(strings.TrimLeft(name, "-"))
+
+	for _, s := range secretFlags {
+		if name == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redact masks the secrets in s with "***", the known ones and anything
+// shaped like one.
+func redact(s string) string {
+	for _, secret := range secretValues() {
+		s = strings.ReplaceAll(s, secret, "***")
+	}
+
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+
+	return s
+}
+
+// redactingWriter redacts what is written to it before passing it on. Every
+// write of the log handlers is a whole record, so no secret is split over
+// two writes.
+type redactingWriter struct {
+	w io.Writer
+}
+
+func (r redactingWriter) Write(p []byte) (int, error) {
+	_, err := io.WriteString(r.w, redact(string(p)))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
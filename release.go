@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var releaseUsage = `Specify a release command to execute:
+  - download: Download the assets of a release`
+
+func executeRelease(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(releaseUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("release", "command", command)
+
+	if piped(args[1:]) {
+		return forEachIdentifier(args[1:], func(args []string) error {
+			return executeRelease(ctx, append([]string{command}, args...))
+		})
+	}
+
+	switch command {
+	case "download":
+		return executeReleaseDownload(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid release command: '%s'", command))
+	}
+}
+
+func executeReleaseDownload(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("release download", flag.ExitOnError)
+
+	tag := flagSet.String("tag", "", "tag of the release, defaults to the latest release")
+	pattern := flagSet.String("pattern", "*", "only download assets matching the glob pattern")
+	dir := flagSet.String("dir", ".", "directory to download the assets to")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: release download <owner/name> --tag <tag>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("release download", "repo", owner+"/"+name, "tag", *tag, "pattern", *pattern)
+
+	var r release
+
+	if *tag == "" {
+		r, err = findLatestRelease(ctx, owner, name)
+	} else {
+		r, err = findReleaseByTag(ctx, owner, name, *tag)
+	}
+	if err != nil {
+		return err
+	}
+
+	assets := make([]releaseAsset, 0)
+
+	for _, a := range r.Assets {
+		matched, err := path.Match(*pattern, a.Name)
+		if err != nil {
+			return usageErrorf("invalid pattern '%s'", *pattern)
+		}
+
+		if matched {
+			assets = append(assets, a)
+		}
+	}
+
+	if len(assets) == 0 {
+		return fmt.Errorf("no assets of release %s match '%s'", r.TagName, *pattern)
+	}
+
+	checksums, err := findReleaseChecksums(ctx, r.Assets)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(*dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range assets {
+		dest := filepath.Join(*dir, a.Name)
+
+		err := downloadFile(ctx, assetPath(a), dest, a.Size)
+		if err != nil {
+			return err
+		}
+
+		if sum, ok := checksums[a.Name]; ok {
+			err := verifySHA256(dest, sum)
+			if err != nil {
+				// Remove the file so the next run downloads it again.
+				os.Remove(dest)
+				return fmt.Errorf("%s: %v", a.Name, err)
+			}
+
+			loggerFrom(ctx).Debug("release download: verified checksum", "asset", a.Name)
+		}
+
+		fmt.Println(dest)
+	}
+
+	return nil
+}
+
+func findReleaseByTag(ctx context.Context, owner, name, tag string) (release, error) {
+	r, err := githubClient().GetReleaseByTag(ctx, owner, name, tag)
+	if err != nil {
+		return release{}, requestError(ctx, err)
+	}
+
+	return *r, nil
+}
+
+// assetPath returns the path of the asset in the api, downloading it from
+// there rather than its browser_download_url sends the token.
+func assetPath(a releaseAsset) string {
+	return strings.TrimPrefix(a.URL, githubAPI)
+}
+
+// isChecksumsAsset reports whether the asset looks like a file of sha256
+// sums, e.g. checksums.txt or SHA256SUMS as published by goreleaser and co.
+func isChecksumsAsset(name string) bool {
+	name = strings.ToLower(name)
+
+	return strings.Contains(name, "checksums") || strings.Contains(name, "sha256sums")
+}
+
+// findReleaseChecksums downloads the checksums file of a release, if there is
+// one, and returns the sha256 sums by file name.
+func findReleaseChecksums(ctx context.Context, assets []releaseAsset) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	for _, a := range assets {
+		if !isChecksumsAsset(a.Name) {
+			continue
+		}
+
+		loggerFrom(ctx).Debug("release download", "checksums_file", a.Name)
+
+		var buf bytes.Buffer
+
+		err := githubClient().GetRaw(ctx, assetPath(a), "application/octet-stream", &buf)
+		if err != nil {
+			return nil, requestError(ctx, err)
+		}
+
+		// Lines are formatted as "<sum>  <file>", binary mode prefixes the
+		// file with a "*".
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) != 2 || len(fields[0]) != 64 {
+				continue
+			}
+
+			checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+		}
+
+		return checksums, scanner.Err()
+	}
+
+	return checksums, nil
+}
@@ -0,0 +1,840 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var repoUsage = `Specify a repo command to execute:
+  - view: Show the details of a repository
+  - readme: Print the readme of a repository
+  - releases: List the releases of a repository
+  - branches: List the branches of a repository
+  - tags: List the tags of a repository
+  - contributors: List the contributors of a repository
+  - languages: Show the languages used in a repository
+  - topics: List, add or remove the topics of a repository
+  - license: Show the license of a repository
+  - cat: Print a file of a repository
+  - ls: List the files of a repository
+  - download: Download an archive of a repository
+  - compare: Compare two branches, tags or commits
+  - traffic: Show the traffic of a repository
+  - sbom: Show the dependencies of a repository
+  - advisories: List the security advisories of a repository
+  - protection: Show the protection rules of a branch
+  - checks: Show the ci results of a commit or branch
+  - stargazers: List the stargazers of a repository
+  - collaborators: List the collaborators of a repository
+  - star: Star repositories
+  - unstar: Unstar repositories
+  - fork: Fork a repository
+  - clone: Clone repositories
+  - create: Create a repository
+  - delete: Delete a repository
+  - events: Show the recent events of a repository`
+
+// repository is a repository as returned by the list and search endpoints.
+type repository = github.Repository
+
+// printRepositories prints a list of repositories as a table or as json.
+func printRepositories(repos []repository) error {
+	if jsonOutput() {
+		return printJSON(repos)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTARS\tFORKS\tLANGUAGE\tPUSHED\tDESCRIPTION")
+
+	for _, r := range repos {
+		name := r.FullName
+		if r.Private {
+			name += " (private)"
+		}
+		if r.Fork {
+			name += " (fork)"
+		}
+
+		language := r.Language
+		if language == "" {
+			language = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n", name, r.StargazersCount, r.ForksCount, language, formatDate(r.PushedAt), truncate(r.Description, 60))
+	}
+
+	return w.Flush()
+}
+
+// repoFilter holds the filters shared by the commands listing repositories.
+type repoFilter struct {
+	sort     string
+	kind     string
+	language string
+}
+
+// addRepoFilterFlags registers the --sort, --type and --language flags.
+func addRepoFilterFlags(flagSet *flag.FlagSet) *repoFilter {
+	filter := &repoFilter{}
+
+	flagSet.StringVar(&filter.sort, "sort", "pushed", "sort by created, updated, pushed or full_name")
+	flagSet.StringVar(&filter.kind, "type", "", "only show forks (fork) or non forks (source)")
+	flagSet.StringVar(&filter.language, "language", "", "only show repositories written in a language")
+
+	return filter
+}
+
+// keep reports whether r passes the filter. The api can not filter by these
+// so it is done while paginating.
+func (f *repoFilter) keep(r repository) bool {
+	if f.kind == "fork" && !r.Fork || f.kind == "source" && r.Fork {
+		return false
+	}
+
+	return f.language == "" || strings.EqualFold(f.language, r.Language)
+}
+
+func (f *repoFilter) validate() error {
+	if f.kind != "" && f.kind != "fork" && f.kind != "source" {
+		return usageErrorf("invalid type '%s', expected fork or source", f.kind)
+	}
+
+	return nil
+}
+
+func executeRepo(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(repoUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("repo", "command", command)
+
+	// A "-" runs the command for every identifier piped to stdin.
+	if command != "clone" && command != "star" && command != "unstar" && piped(args[1:]) {
+		return forEachIdentifier(args[1:], func(args []string) error {
+			return executeRepo(ctx, append([]string{command}, args...))
+		})
+	}
+
+	switch command {
+	case "view":
+		return executeRepoView(ctx, args[1:])
+	case "readme":
+		return executeRepoReadme(ctx, args[1:])
+	case "releases":
+		return executeRepoReleases(ctx, args[1:])
+	case "branches":
+		return executeRepoBranches(ctx, args[1:])
+	case "tags":
+		return executeRepoTags(ctx, args[1:])
+	case "contributors":
+		return executeRepoContributors(ctx, args[1:])
+	case "languages":
+		return executeRepoLanguages(ctx, args[1:])
+	case "topics":
+		return executeRepoTopics(ctx, args[1:])
+	case "license":
+		return executeRepoLicense(ctx, args[1:])
+	case "cat":
+		return executeRepoCat(ctx, args[1:])
+	case "ls":
+		return executeRepoLs(ctx, args[1:])
+	case "download":
+		return executeRepoDownload(ctx, args[1:])
+	case "compare":
+		return executeRepoCompare(ctx, args[1:])
+	case "traffic":
+		return executeRepoTraffic(ctx, args[1:])
+	case "sbom":
+		return executeRepoSBOM(ctx, args[1:])
+	case "advisories":
+		return executeRepoAdvisories(ctx, args[1:])
+	case "protection":
+		return executeRepoProtection(ctx, args[1:])
+	case "checks":
+		return executeRepoChecks(ctx, args[1:])
+	case "stargazers":
+		return executeRepoStargazers(ctx, args[1:])
+	case "collaborators":
+		return executeRepoCollaborators(ctx, args[1:])
+	case "star", "unstar":
+		return executeRepoStar(ctx, command, args[1:])
+	case "fork":
+		return executeRepoFork(ctx, args[1:])
+	case "clone":
+		return executeRepoClone(ctx, args[1:])
+	case "create":
+		return executeRepoCreate(ctx, args[1:])
+	case "delete":
+		return executeRepoDelete(ctx, args[1:])
+	case "events":
+		return executeRepoEvents(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid repo command: '%s'", command))
+	}
+}
+
+func executeRepoView(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo view", flag.ExitOnError)
+
+	web := flagSet.Bool("web", false, "open the repository in the browser")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo view <owner/name>, - reads repositories from stdin")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo view", "repo", owner+"/"+name)
+
+	if *web {
+		return openBrowser(ctx, fmt.Sprintf("https://github.com/%s/%s", owner, name))
+	}
+
+	repo, err := githubClient().GetRepository(ctx, owner, name)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	indexRepositories(ctx, []repository{*repo})
+
+	if jsonOutput() {
+		return printJSON(repo)
+	}
+
+	fmt.Println(bold(repo.FullName))
+
+	if repo.Description != "" {
+		fmt.Println(repo.Description)
+	}
+
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	if repo.Language != "" {
+		fmt.Fprintf(w, "Language:\t%s\n", repo.Language)
+	}
+	if len(repo.Topics) > 0 {
+		fmt.Fprintf(w, "Topics:\t%s\n", strings.Join(repo.Topics, ", "))
+	}
+
+	fmt.Fprintf(w, "Stars:\t%d\n", repo.StargazersCount)
+	fmt.Fprintf(w, "Forks:\t%d\n", repo.ForksCount)
+
+	if repo.DefaultBranch != "" {
+		fmt.Fprintf(w, "Default branch:\t%s\n", repo.DefaultBranch)
+	}
+
+	fmt.Fprintf(w, "Pushed:\t%s\n", formatDate(repo.PushedAt))
+
+	var flags []string
+	if repo.Private {
+		flags = append(flags, "private")
+	}
+	if repo.Fork {
+		flags = append(flags, "fork")
+	}
+	if repo.Archived {
+		flags = append(flags, "archived")
+	}
+	if len(flags) > 0 {
+		fmt.Fprintf(w, "Flags:\t%s\n", strings.Join(flags, ", "))
+	}
+
+	fmt.Fprintf(w, "URL:\t%s\n", repo.HTMLURL)
+
+	return w.Flush()
+}
+
+func executeRepoReadme(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo readme", flag.ExitOnError)
+
+	render := flagSet.Bool("render", false, "render the markdown for the terminal")
+	output := flagSet.String("output", "", "save the readme to a file instead of printing it")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo readme <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo readme", "repo", owner+"/"+name)
+
+	readme, err := findReadme(ctx, owner, name)
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		return os.WriteFile(*output, []byte(readme), 0o644)
+	}
+
+	if *render {
+		readme = renderMarkdown(readme)
+	}
+
+	fmt.Println(readme)
+
+	return nil
+}
+
+func findReadme(ctx context.Context, owner, name string) (string, error) {
+	readme, err := githubClient().GetReadme(ctx, owner, name)
+	if err != nil {
+		return "", requestError(ctx, err)
+	}
+
+	return decodeContent(ctx, readme.Content, readme.Encoding)
+}
+
+// decodeContent decodes file contents returned by the contents api.
+func decodeContent(ctx context.Context, content, encoding string) (string, error) {
+	if encoding != "base64" {
+		return content, nil
+	}
+
+	// Github wraps the base64 content over multiple lines.
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content, "\n", ""))
+	if err != nil {
+		loggerFrom(ctx).Debug("invalid file contents", "err", err)
+		return "", errors.New("failed to decode the file contents")
+	}
+
+	return string(decoded), nil
+}
+
+type releaseAsset = github.ReleaseAsset
+
+type release = github.Release
+
+func executeRepoReleases(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo releases", flag.ExitOnError)
+
+	latest := flagSet.Bool("latest", false, "only print the newest release")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo releases <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo releases", "repo", owner+"/"+name)
+
+	var releases []release
+
+	if *latest {
+		r, err := findLatestRelease(ctx, owner, name)
+		if err != nil {
+			return err
+		}
+		releases = []release{r}
+	} else {
+		releases, err = collectPages(ctx, githubClient().ListReleases(ctx, owner, name), page.max(), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput() {
+		return printJSON(releases)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TAG\tNAME\tPUBLISHED\tSTATUS\tASSETS")
+
+	for _, r := range releases {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", r.TagName, r.Name, formatDate(r.PublishedAt), releaseStatus(r), len(r.Assets))
+	}
+
+	return w.Flush()
+}
+
+func releaseStatus(r release) string {
+	switch {
+	case r.Draft:
+		return "draft"
+	case r.Prerelease:
+		return "prerelease"
+	default:
+		return "release"
+	}
+}
+
+func findLatestRelease(ctx context.Context, owner, name string) (release, error) {
+	r, err := githubClient().GetLatestRelease(ctx, owner, name)
+	if err != nil {
+		return release{}, requestError(ctx, err)
+	}
+
+	return *r, nil
+}
+
+// formatDate formats t as a date, drafts and other unset times are shown as "-".
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format("2006-01-02")
+}
+
+type branch = github.Branch
+
+func executeRepoBranches(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo branches", flag.ExitOnError)
+
+	defaultOnly := flagSet.Bool("default-only", false, "only show the default branch")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo branches <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo branches", "repo", owner+"/"+name)
+
+	var branches []branch
+
+	if *defaultOnly {
+		b, err := findDefaultBranch(ctx, owner, name)
+		if err != nil {
+			return err
+		}
+		branches = []branch{b}
+	} else {
+		branches, err = collectPages(ctx, githubClient().ListBranches(ctx, owner, name), page.max(), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSHA\tPROTECTED")
+
+	for _, b := range branches {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", b.Name, b.Commit.SHA, b.Protected)
+	}
+
+	return w.Flush()
+}
+
+func findDefaultBranch(ctx context.Context, owner, name string) (branch, error) {
+	client := githubClient()
+
+	repo, err := client.GetRepository(ctx, owner, name)
+	if err != nil {
+		return branch{}, requestError(ctx, err)
+	}
+
+	b, err := client.GetBranch(ctx, owner, name, repo.DefaultBranch)
+	if err != nil {
+		return branch{}, requestError(ctx, err)
+	}
+
+	return *b, nil
+}
+
+type tag = github.Tag
+
+func executeRepoTags(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo tags", flag.ExitOnError)
+
+	bySemver := flagSet.Bool("semver", false, "sort the tags by semantic version, newest first")
+	latest := flagSet.Bool("latest", false, "only print the newest stable version tag")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo tags <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo tags", "repo", owner+"/"+name)
+
+	// The api does not order tags by version, finding the latest one
+	// requires looking at all of them.
+	limit := page.max()
+	if *latest {
+		limit = 0
+	}
+
+	tags, err := collectPages(ctx, githubClient().ListTags(ctx, owner, name), limit, nil)
+	if err != nil {
+		return err
+	}
+
+	if *bySemver || *latest {
+		sortTagsBySemver(tags)
+	}
+
+	if *latest {
+		for _, t := range tags {
+			if v, ok := parseSemver(t.Name); ok && len(v.prerelease) == 0 {
+				fmt.Println(t.Name)
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no version tags found in %s/%s", owner, name)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSHA")
+
+	for _, t := range tags {
+		fmt.Fprintf(w, "%s\t%s\n", t.Name, t.Commit.SHA)
+	}
+
+	return w.Flush()
+}
+
+// sortTagsBySemver sorts the tags newest version first, tags that are not a
+// semantic version keep their order at the end.
+func sortTagsBySemver(tags []tag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		vi, okI := parseSemver(tags[i].Name)
+		vj, okJ := parseSemver(tags[j].Name)
+
+		if !okI || !okJ {
+			return okI && !okJ
+		}
+
+		return vi.compare(vj) > 0
+	})
+}
+
+type contributor = github.Contributor
+
+func executeRepoContributors(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo contributors", flag.ExitOnError)
+
+	top := flagSet.Int("top", 30, "only show the top N contributors, 0 shows all of them")
+	anon := flagSet.Bool("anon", false, "include anonymous contributors")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo contributors <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo contributors", "repo", owner+"/"+name)
+
+	// Contributors are returned ordered by the number of contributions.
+	contributors, err := collectPages(ctx, githubClient().ListContributors(ctx, owner, name, *anon), *top, nil)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOGIN\tCONTRIBUTIONS")
+
+	for _, c := range contributors {
+		login := c.Login
+		if c.Type == "Anonymous" {
+			login = fmt.Sprintf("%s (anonymous)", c.Name)
+		}
+
+		fmt.Fprintf(w, "%s\t%d\n", login, c.Contributions)
+	}
+
+	return w.Flush()
+}
+
+const languageBarWidth = 40
+
+func executeRepoLanguages(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo languages", flag.ExitOnError)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo languages <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo languages", "repo", owner+"/"+name)
+
+	// Maps the language to the number of bytes of code written in it.
+	languages, err := githubClient().GetLanguages(ctx, owner, name)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	if jsonOutput() {
+		return printJSON(languages)
+	}
+
+	names := make([]string, 0, len(languages))
+	total := int64(0)
+
+	for language, bytes := range languages {
+		names = append(names, language)
+		total += bytes
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return languages[names[i]] > languages[names[j]]
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, language := range names {
+		percent := 100 * float64(languages[language]) / float64(total)
+		bar := strings.Repeat("█", int(percent*languageBarWidth/100))
+
+		fmt.Fprintf(w, "%s\t%5.1f%%\t%s\n", language, percent, bar)
+	}
+
+	return w.Flush()
+}
+
+func executeRepoTopics(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo topics", flag.ExitOnError)
+
+	add := stringList{}
+	remove := stringList{}
+	flagSet.Var(&add, "add", "topics to add, requires authentication")
+	flagSet.Var(&remove, "remove", "topics to remove, requires authentication")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo topics <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo topics", "repo", owner+"/"+name, "add", add, "remove", remove)
+
+	client := githubClient()
+
+	current, err := client.GetTopics(ctx, owner, name)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	if len(add) > 0 || len(remove) > 0 {
+		err := requireToken()
+		if err != nil {
+			return err
+		}
+
+		// The api replaces all topics at once, so the new set is computed
+		// from the current one.
+		updated := make([]string, 0)
+		removed := make(map[string]bool)
+
+		for _, t := range remove {
+			removed[strings.ToLower(t)] = true
+		}
+
+		for _, t := range append(current, add...) {
+			t = strings.ToLower(t)
+			if !removed[t] && !containsString(updated, t) {
+				updated = append(updated, t)
+			}
+		}
+
+		current, err = client.ReplaceTopics(ctx, owner, name, updated)
+		if err != nil {
+			return requestError(ctx, err)
+		}
+	}
+
+	if current == nil {
+		current = make([]string, 0)
+	}
+
+	if jsonOutput() {
+		return printJSON(current)
+	}
+
+	for _, t := range current {
+		fmt.Println(t)
+	}
+
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+type repoLicense = github.RepositoryLicense
+
+func executeRepoLicense(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo license", flag.ExitOnError)
+
+	full := flagSet.Bool("full", false, "print the full license text")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo license <owner/name>...")
+	}
+
+	if len(args) > 1 && !*full {
+		return executeRepoLicenses(ctx, args)
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo license", "repo", owner+"/"+name)
+
+	license, err := findLicense(ctx, owner, name)
+	if err != nil {
+		return err
+	}
+
+	if *full {
+		text, err := decodeContent(ctx, license.Content, license.Encoding)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(text)
+		return nil
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string]string{
+			"spdx_id": license.License.SPDXID,
+			"name":    license.License.Name,
+		})
+	}
+
+	fmt.Printf("%s (%s)\n", license.License.Name, license.License.SPDXID)
+
+	return nil
+}
+
+// executeRepoLicenses prints the licenses of several repositories as a
+// table, for checking the licenses of a list of search results at once.
+func executeRepoLicenses(ctx context.Context, repos []string) error {
+	type result struct {
+		Repo   string `json:"repo"`
+		SPDXID string `json:"spdx_id"`
+		Name   string `json:"name"`
+	}
+
+	results := make([]result, 0, len(repos))
+
+	for _, repo := range repos {
+		owner, name, err := parseRepoName(repo)
+		if err != nil {
+			return err
+		}
+
+		r := result{Repo: repo, SPDXID: "-", Name: "-"}
+
+		// Only repositories without a license are shown as "-", a rate
+		// limit or a failed request fails the whole table.
+		license, err := findLicense(ctx, owner, name)
+		switch {
+		case errors.Is(err, errNotFound):
+			loggerFrom(ctx).Debug("repo license", "repo", repo, "err", err)
+		case err != nil:
+			return err
+		default:
+			r.SPDXID, r.Name = license.License.SPDXID, license.License.Name
+		}
+
+		results = append(results, r)
+	}
+
+	if jsonOutput() {
+		return printJSON(results)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tSPDX\tNAME")
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Repo, r.SPDXID, r.Name)
+	}
+
+	return w.Flush()
+}
+
+func findLicense(ctx context.Context, owner, name string) (repoLicense, error) {
+	license, err := githubClient().GetLicense(ctx, owner, name)
+	err = requestError(ctx, err)
+	if errors.Is(err, errNotFound) {
+		return repoLicense{}, &githubError{Category: categoryNotFound, Message: fmt.Sprintf("no license detected for %s/%s", owner, name), Err: err}
+	}
+	if err != nil {
+		return repoLicense{}, err
+	}
+
+	return *license, nil
+}
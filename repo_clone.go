@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// cloneURL returns the git url of a repository for the protocol, https or
+// ssh.
+func cloneURL(owner, name, protocol string) (string, error) {
+	switch protocol {
+	case "https":
+		return fmt.Sprintf("https://github.com/%s/%s.git", owner, name), nil
+	case "ssh":
+		return fmt.Sprintf("git@github.com:%s/%s.git", owner, name), nil
+	default:
+		return "", usageErrorf("invalid git protocol '%s', expected https or ssh", protocol)
+	}
+}
+
+func executeRepoClone(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo clone", flag.ExitOnError)
+
+	depth := flagSet.Int("depth", 0, "create a shallow clone with that many commits")
+	protocol := flagSet.String("protocol", "", "git protocol to clone with, https or ssh, defaults to git_protocol of the config")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo clone <owner/name> [dir], - reads repositories from stdin")
+	}
+
+	if *protocol == "" {
+		cfg, err := loadConfig(ctx)
+		if err != nil {
+			return err
+		}
+		*protocol = cfg.GitProtocol
+	}
+
+	loggerFrom(ctx).Debug("repo clone", "repos", args, "protocol", *protocol, "depth", *depth)
+
+	if args[0] != "-" {
+		dir := ""
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		return cloneRepo(ctx, args[0], dir, *protocol, *depth)
+	}
+
+	// With "-" every repository piped in is cloned into its own directory,
+	// e.g. from search-repos -q.
+	repos, err := readLines("-")
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, repo := range repos {
+		err := cloneRepo(ctx, repo, "", *protocol, *depth)
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", repo, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to clone %d of %d repositories", failed, len(repos))
+	}
+
+	return nil
+}
+
+func cloneRepo(ctx context.Context, repo, dir, protocol string, depth int) error {
+	owner, name, err := parseRepoName(repo)
+	if err != nil {
+		return err
+	}
+
+	url, err := cloneURL(owner, name, protocol)
+	if err != nil {
+		return err
+	}
+
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+
+	args = append(args, url)
+	if dir != "" {
+		args = append(args, dir)
+	}
+
+	return runGit(ctx, args...)
+}
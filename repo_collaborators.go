@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+func executeRepoCollaborators(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo collaborators", flag.ExitOnError)
+
+	affiliation := flagSet.String("affiliation", "all", "only show collaborators with an affiliation: direct, outside or all")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo collaborators <owner/name>")
+	}
+
+	if *affiliation != "all" && *affiliation != "direct" && *affiliation != "outside" {
+		return usageErrorf("invalid affiliation '%s', expected direct, outside or all", *affiliation)
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo collaborators", "repo", owner+"/"+name, "affiliation", *affiliation)
+
+	collaborators, err := collectPages(ctx, githubClient().ListCollaborators(ctx, owner, name, *affiliation), page.max(), nil)
+	if errors.Is(err, errForbidden) {
+		return fmt.Errorf("listing collaborators of %s/%s requires push access to the repository", owner, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(collaborators)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LOGIN\tPERMISSION")
+
+	for _, c := range collaborators {
+		fmt.Fprintf(w, "%s\t%s\n", c.Login, c.Permission())
+	}
+
+	return w.Flush()
+}
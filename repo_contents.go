@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+func executeRepoCat(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo cat", flag.ExitOnError)
+
+	ref := flagSet.String("ref", "", "branch, tag or commit to read the file from, defaults to the default branch")
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) < 2 {
+		return usageError("provide a repository and a file: repo cat <owner/name> <path>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	filePath := strings.Trim(args[1], "/")
+
+	loggerFrom(ctx).Debug("repo cat", "repo", owner+"/"+name, "path", filePath, "ref", *ref)
+
+	f, err := githubClient().GetFile(ctx, owner, name, filePath, *ref)
+	if errors.Is(err, github.ErrDirectory) {
+		return fmt.Errorf("'%s' is a directory, use repo ls to list it", filePath)
+	}
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	// Files larger than 1MB come without content and have to be downloaded.
+	if f.Encoding == "none" {
+		return streamURL(ctx, f.DownloadURL, os.Stdout)
+	}
+
+	content, err := decodeContent(ctx, f.Content, f.Encoding)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(os.Stdout, content)
+
+	return err
+}
+
+// streamURL copies the body of a plain get request to url into w.
+func streamURL(ctx context.Context, url string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		// The url is from the answer of github.
+		return invalidResponse(ctx, err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return networkError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(ctx, res)
+	}
+
+	_, err = io.Copy(w, res.Body)
+
+	return err
+}
+
+type treeEntry = github.TreeEntry
+
+func executeRepoLs(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo ls", flag.ExitOnError)
+
+	ref := flagSet.String("ref", "HEAD", "branch, tag or commit to list")
+	recursive := flagSet.Bool("recursive", false, "list the contents of sub directories")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo ls <owner/name> [path]")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	dir := ""
+	if len(args) > 1 {
+		dir = strings.Trim(args[1], "/")
+	}
+
+	loggerFrom(ctx).Debug("repo ls", "repo", owner+"/"+name, "path", dir, "ref", *ref)
+
+	// The whole tree is fetched and filtered locally, that way listing a sub
+	// directory does not require resolving its tree sha first.
+	result, err := githubClient().GetTree(ctx, owner, name, *ref, true)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	if result.Truncated {
+		loggerFrom(ctx).Warn("the repository is too large, the listing is incomplete")
+	}
+
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	entries := make([]treeEntry, 0)
+
+	for _, e := range result.Tree {
+		if !strings.HasPrefix(e.Path, prefix) {
+			continue
+		}
+
+		e.Path = strings.TrimPrefix(e.Path, prefix)
+
+		if !*recursive && strings.Contains(e.Path, "/") {
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	if len(entries) == 0 && dir != "" {
+		return fmt.Errorf("no such directory '%s'", dir)
+	}
+
+	if jsonOutput() {
+		return printJSON(entries)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	for _, e := range entries {
+		size := "-"
+		if e.Type == "blob" {
+			size = formatBytes(e.Size)
+		}
+
+		path := e.Path
+		if e.Type == "tree" {
+			path += "/"
+		}
+
+		fmt.Fprintf(w, "%s\t  %s\n", size, path)
+	}
+
+	return w.Flush()
+}
+
+func executeRepoDownload(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo download", flag.ExitOnError)
+
+	ref := flagSet.String("ref", "", "branch, tag or commit to download, defaults to the default branch")
+	dir := flagSet.String("dir", ".", "directory to save the archive to")
+	useZip := flagSet.Bool("zip", false, "download a zip archive instead of a tarball")
+	extract := flagSet.Bool("extract", false, "extract the archive into --dir and remove it")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo download <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo download", "repo", owner+"/"+name, "ref", *ref)
+
+	kind, ext := "tarball", ".tar.gz"
+	if *useZip {
+		kind, ext = "zipball", ".zip"
+	}
+
+	label := *ref
+	if label == "" {
+		label = "HEAD"
+	}
+
+	err = os.MkdirAll(*dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	archive := filepath.Join(*dir, fmt.Sprintf("%s-%s%s", name, strings.ReplaceAll(label, "/", "-"), ext))
+
+	// The api redirects to the archive, which is streamed to disk instead of
+	// decoded like other responses.
+	res, err := githubClient().OpenArchive(ctx, owner, name, kind, *ref)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+	defer res.Body.Close()
+
+	file, err := os.Create(archive)
+	if err != nil {
+		return err
+	}
+
+	progress := newProgressBar(filepath.Base(archive), res.ContentLength, 0)
+
+	_, err = io.Copy(io.MultiWriter(file, progress), res.Body)
+	progress.Finish()
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archive)
+		return err
+	}
+
+	if !*extract {
+		fmt.Println(archive)
+		return nil
+	}
+
+	if *useZip {
+		err = extractZip(ctx, archive, *dir)
+	} else {
+		err = extractTarGz(ctx, archive, *dir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %v", archive, err)
+	}
+
+	fmt.Println(*dir)
+
+	return os.Remove(archive)
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+// forkTimeout is how long to wait for github to finish creating a fork.
+const forkTimeout = 2 * time.Minute
+
+func executeRepoFork(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo fork", flag.ExitOnError)
+
+	org := flagSet.String("org", "", "organization to create the fork in, defaults to your account")
+	clone := flagSet.Bool("clone", false, "clone the fork into the current directory")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo fork <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo fork", "repo", owner+"/"+name, "org", *org)
+
+	client := githubClient()
+
+	fork, err := client.CreateFork(ctx, owner, name, &github.ForkOptions{Organization: *org})
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	// Forking happens asynchronously, the fork answers with a 404 until it
+	// has been created.
+	fmt.Fprintf(os.Stderr, "Waiting for %s to be created...\n", fork.FullName)
+
+	giveUp := time.Now().Add(forkTimeout)
+
+	forkOwner, forkName, _ := strings.Cut(fork.FullName, "/")
+
+	for {
+		_, err := client.GetRepository(ctx, forkOwner, forkName)
+		if err == nil {
+			break
+		}
+
+		err = requestError(ctx, err)
+
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+
+		if time.Now().After(giveUp) {
+			return fmt.Errorf("timed out waiting for %s, it may still show up later", fork.FullName)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+
+	fmt.Println(fork.HTMLURL)
+
+	if !*clone {
+		return nil
+	}
+
+	// Github may pick another name for the fork when the name is taken.
+	err = runGit(ctx, "clone", fork.CloneURL, forkName)
+	if err != nil {
+		return err
+	}
+
+	// Make it easy to keep the fork up to date with the original.
+	return runGit(ctx, "-C", forkName, "remote", "add", "upstream", fmt.Sprintf("https://github.com/%s/%s.git", owner, name))
+}
+
+func executeRepoCreate(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo create", flag.ExitOnError)
+
+	private := flagSet.Bool("private", false, "make the repository private")
+	description := flagSet.String("description", "", "description of the repository")
+	gitignore := flagSet.String("gitignore", "", "gitignore template to add, e.g. Go")
+	license := flagSet.String("license", "", "license template to add, e.g. mit")
+	push := flagSet.Bool("push", false, "push the current directory to the new repository")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository name")
+
+	if len(args) == 0 {
+		return usageError("provide a name: repo create <name> or repo create <org>/<name>")
+	}
+
+	// The initial commit github creates for the templates would conflict
+	// with the pushed history.
+	if *push && (*gitignore != "" || *license != "") {
+		return usageError("--push can not be combined with --gitignore or --license")
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	org, name := "", args[0]
+	if owner, repoName, found := strings.Cut(args[0], "/"); found {
+		org, name = owner, repoName
+	}
+
+	loggerFrom(ctx).Debug("repo create", "org", org, "name", name, "private", *private)
+
+	repo, err := githubClient().CreateRepository(ctx, org, &github.RepositoryRequest{
+		Name:              name,
+		Private:           *private,
+		Description:       *description,
+		GitignoreTemplate: *gitignore,
+		LicenseTemplate:   *license,
+	})
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	fmt.Println(repo.HTMLURL)
+
+	if !*push {
+		return nil
+	}
+
+	return pushCurrentDir(ctx, repo.CloneURL)
+}
+
+// pushCurrentDir pushes the current directory to remote as origin, turning
+// it into a git repository with an initial commit first when needed.
+func pushCurrentDir(ctx context.Context, remote string) error {
+	if _, err := os.Stat(".git"); os.IsNotExist(err) {
+		err := runGit(ctx, "init")
+		if err != nil {
+			return err
+		}
+	}
+
+	if runGitQuiet(ctx, "rev-parse", "--verify", "HEAD") != nil {
+		err := runGit(ctx, "add", "-A")
+		if err != nil {
+			return err
+		}
+
+		err = runGit(ctx, "commit", "-m", "Initial commit")
+		if err != nil {
+			return err
+		}
+	}
+
+	err := runGit(ctx, "remote", "add", "origin", remote)
+	if err != nil {
+		return err
+	}
+
+	return runGit(ctx, "push", "-u", "origin", "HEAD")
+}
+
+func executeRepoDelete(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo delete", flag.ExitOnError)
+
+	yes := flagSet.Bool("yes", false, "delete without asking for confirmation")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo delete <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	fullName := owner + "/" + name
+
+	err = confirmTyped(fmt.Sprintf("This permanently deletes %s. Type the name of the repository to confirm", fullName), fullName, *yes)
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo delete", "repo", fullName)
+
+	err = requestError(ctx, githubClient().DeleteRepository(ctx, owner, name))
+
+	var ghErr *githubError
+	var apiErr *github.Error
+	if errors.As(err, &ghErr) && ghErr.Category == categoryAuth && ghErr.StatusCode == http.StatusForbidden && errors.As(err, &apiErr) {
+		// Classic tokens list their scopes, fine grained tokens do not.
+		scopes := apiErr.Response.Header.Get("X-OAuth-Scopes")
+		if scopes != "" && !strings.Contains(scopes, "delete_repo") {
+			ghErr.Message = "your token lacks the delete_repo scope required to delete repositories"
+			ghErr.Hint = "Add the delete_repo scope to the token at https://github.com/settings/tokens"
+		} else {
+			ghErr.Message = fmt.Sprintf("deleting %s requires admin access to the repository", fullName)
+		}
+
+		return ghErr
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted %s\n", fullName)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// branchProtection is a flattened summary of the protection rules of a
+// branch, stable enough to be diffed by auditing scripts.
+type branchProtection struct {
+	Repo                 string   `json:"repo"`
+	Branch               string   `json:"branch"`
+	RequiredReviews      int      `json:"required_reviews"`
+	DismissStaleReviews  bool     `json:"dismiss_stale_reviews"`
+	RequireCodeOwners    bool     `json:"require_code_owner_reviews"`
+	StrictStatusChecks   bool     `json:"strict_status_checks"`
+	RequiredStatusChecks []string `json:"required_status_checks"`
+	EnforceAdmins        bool     `json:"enforce_admins"`
+	RequiredSignatures   bool     `json:"required_signatures"`
+}
+
+func executeRepoProtection(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo protection", flag.ExitOnError)
+
+	branchName := flagSet.String("branch", "", "branch to inspect, defaults to the default branch")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo protection <owner/name> --branch <branch>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	if *branchName == "" {
+		b, err := findDefaultBranch(ctx, owner, name)
+		if err != nil {
+			return err
+		}
+		*branchName = b.Name
+	}
+
+	loggerFrom(ctx).Debug("repo protection", "repo", owner+"/"+name, "branch", *branchName)
+
+	protection, err := findBranchProtection(ctx, owner, name, *branchName)
+	if errors.Is(err, errNotFound) {
+		return fmt.Errorf("branch '%s' of %s/%s is not protected", *branchName, owner, name)
+	}
+	if errors.Is(err, errForbidden) {
+		return fmt.Errorf("inspecting branch protection of %s/%s requires admin access to the repository", owner, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(protection)
+	}
+
+	checks := strings.Join(protection.RequiredStatusChecks, ", ")
+	if checks == "" {
+		checks = "none"
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Branch:\t%s/%s@%s\n", owner, name, protection.Branch)
+	fmt.Fprintf(w, "Required reviews:\t%d\n", protection.RequiredReviews)
+	fmt.Fprintf(w, "Dismiss stale reviews:\t%t\n", protection.DismissStaleReviews)
+	fmt.Fprintf(w, "Code owner reviews:\t%t\n", protection.RequireCodeOwners)
+	fmt.Fprintf(w, "Required status checks:\t%s\n", checks)
+	fmt.Fprintf(w, "Up to date before merge:\t%t\n", protection.StrictStatusChecks)
+	fmt.Fprintf(w, "Enforce admins:\t%t\n", protection.EnforceAdmins)
+	fmt.Fprintf(w, "Signed commits:\t%t\n", protection.RequiredSignatures)
+
+	return w.Flush()
+}
+
+func findBranchProtection(ctx context.Context, owner, name, branchName string) (branchProtection, error) {
+	result, err := githubClient().GetBranchProtection(ctx, owner, name, branchName)
+	if err != nil {
+		return branchProtection{}, requestError(ctx, err)
+	}
+
+	protection := branchProtection{
+		Repo:                 owner + "/" + name,
+		Branch:               branchName,
+		RequiredStatusChecks: make([]string, 0),
+		EnforceAdmins:        result.EnforceAdmins.Enabled,
+		RequiredSignatures:   result.RequiredSignatures.Enabled,
+	}
+
+	// Rules that are not configured are left out by the api.
+	if r := result.RequiredPullRequestReviews; r != nil {
+		protection.RequiredReviews = r.RequiredApprovingReviewCount
+		protection.DismissStaleReviews = r.DismissStaleReviews
+		protection.RequireCodeOwners = r.RequireCodeOwnerReviews
+	}
+
+	if r := result.RequiredStatusChecks; r != nil {
+		protection.StrictStatusChecks = r.Strict
+		protection.RequiredStatusChecks = append(protection.RequiredStatusChecks, r.Contexts...)
+	}
+
+	return protection, nil
+}
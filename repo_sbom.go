@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+type spdxDocument struct {
+	Packages []struct {
+		SPDXID       string `json:"SPDXID"`
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+	Relationships []struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+		RelationshipType   string `json:"relationshipType"`
+	} `json:"relationships"`
+}
+
+func executeRepoSBOM(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo sbom", flag.ExitOnError)
+
+	spdx := flagSet.Bool("spdx", false, "print the sbom as spdx json instead of a summary")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo sbom <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo sbom", "repo", owner+"/"+name)
+
+	sbom, err := githubClient().GetSBOM(ctx, owner, name)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	// The document is printed as returned so no spdx fields are lost.
+	if *spdx || jsonOutput() {
+		return printJSON(sbom)
+	}
+
+	doc := spdxDocument{}
+
+	err = json.Unmarshal(sbom, &doc)
+	if err != nil {
+		loggerFrom(ctx).Debug("invalid response", "err", err)
+		return errors.New("failed to parse the sbom")
+	}
+
+	type dependency struct {
+		ecosystem, name, version string
+	}
+
+	topLevel := topLevelPackages(doc)
+	deps := make([]dependency, 0)
+	counts := make(map[string]int)
+
+	for _, p := range doc.Packages {
+		if !topLevel[p.SPDXID] {
+			continue
+		}
+
+		d := dependency{ecosystem: "unknown", name: p.Name, version: p.VersionInfo}
+
+		// Package urls look like pkg:golang/github.com/pkg/errors@v0.9.1.
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				d.ecosystem = strings.SplitN(strings.TrimPrefix(ref.ReferenceLocator, "pkg:"), "/", 2)[0]
+			}
+		}
+
+		deps = append(deps, d)
+		counts[d.ecosystem]++
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].ecosystem != deps[j].ecosystem {
+			return deps[i].ecosystem < deps[j].ecosystem
+		}
+		return deps[i].name < deps[j].name
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ECOSYSTEM\tPACKAGE\tVERSION")
+
+	for _, d := range deps {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.ecosystem, d.name, d.version)
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	ecosystems := make([]string, 0, len(counts))
+	for e := range counts {
+		ecosystems = append(ecosystems, e)
+	}
+	sort.Strings(ecosystems)
+
+	summary := make([]string, 0, len(ecosystems))
+	for _, e := range ecosystems {
+		summary = append(summary, fmt.Sprintf("%s: %d", e, counts[e]))
+	}
+
+	fmt.Printf("\n%d top-level dependencies (%s)\n", len(deps), strings.Join(summary, ", "))
+
+	return nil
+}
+
+// topLevelPackages returns the ids of the packages the repository directly
+// depends on. When the document has no dependency relationships every
+// package except the repository itself is considered top-level.
+func topLevelPackages(doc spdxDocument) map[string]bool {
+	roots := make(map[string]bool)
+
+	for _, r := range doc.Relationships {
+		if r.SPDXElementID == "SPDXRef-DOCUMENT" && r.RelationshipType == "DESCRIBES" {
+			roots[r.RelatedSPDXElement] = true
+		}
+	}
+
+	topLevel := make(map[string]bool)
+
+	for _, r := range doc.Relationships {
+		if roots[r.SPDXElementID] && r.RelationshipType == "DEPENDS_ON" {
+			topLevel[r.RelatedSPDXElement] = true
+		}
+	}
+
+	if len(topLevel) == 0 {
+		for _, p := range doc.Packages {
+			if !roots[p.SPDXID] {
+				topLevel[p.SPDXID] = true
+			}
+		}
+	}
+
+	return topLevel
+}
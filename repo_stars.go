@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+const histogramBarWidth = 40
+
+type stargazer = github.Stargazer
+
+func executeRepoStargazers(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo stargazers", flag.ExitOnError)
+
+	histogram := flagSet.Bool("histogram", false, "show the number of stars per month as a chart")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo stargazers <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo stargazers", "repo", owner+"/"+name, "histogram", *histogram)
+
+	// The chart covers the whole history of the repository.
+	limit := page.max()
+	if *histogram {
+		limit = 0
+	}
+
+	stargazers, err := collectPages(ctx, githubClient().ListStargazers(ctx, owner, name), limit, nil)
+	if err != nil {
+		return err
+	}
+
+	if *histogram {
+		return printStarHistogram(stargazers)
+	}
+
+	if jsonOutput() {
+		return printJSON(stargazers)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STARRED\tLOGIN")
+
+	for _, s := range stargazers {
+		fmt.Fprintf(w, "%s\t%s\n", formatDate(s.StarredAt), s.User.Login)
+	}
+
+	return w.Flush()
+}
+
+// printStarHistogram buckets the stars per month and prints them as a bar
+// chart, months without stars are included so the time axis is continuous.
+func printStarHistogram(stargazers []stargazer) error {
+	counts := make(map[string]int)
+	first, last := time.Time{}, time.Time{}
+
+	for _, s := range stargazers {
+		month := time.Date(s.StarredAt.Year(), s.StarredAt.Month(), 1, 0, 0, 0, 0, time.UTC)
+		counts[month.Format("2006-01")]++
+
+		if first.IsZero() || month.Before(first) {
+			first = month
+		}
+		if month.After(last) {
+			last = month
+		}
+	}
+
+	type bucket struct {
+		Month string `json:"month"`
+		Stars int    `json:"stars"`
+	}
+
+	buckets := make([]bucket, 0)
+	max := 0
+
+	for month := first; !first.IsZero() && !month.After(last); month = month.AddDate(0, 1, 0) {
+		b := bucket{Month: month.Format("2006-01"), Stars: counts[month.Format("2006-01")]}
+		buckets = append(buckets, b)
+
+		if b.Stars > max {
+			max = b.Stars
+		}
+	}
+
+	if jsonOutput() {
+		return printJSON(buckets)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, b := range buckets {
+		bar := strings.Repeat("█", b.Stars*histogramBarWidth/max)
+		fmt.Fprintf(w, "%s\t%d\t%s\n", b.Month, b.Stars, bar)
+	}
+
+	return w.Flush()
+}
+
+// executeRepoStar stars or unstars repositories, depending on the command
+// which is either star or unstar.
+func executeRepoStar(ctx context.Context, command string, args []string) error {
+	flagSet := flag.NewFlagSet("repo "+command, flag.ExitOnError)
+
+	fromFile := flagSet.String("from-file", "", "file with a repository per line to "+command+", - reads stdin")
+	yes := flagSet.Bool("yes", false, "do not ask for confirmation when unstarring or starring many repositories")
+
+	repos := make([]string, 0)
+
+	for _, arg := range parseArgs(flagSet, args) {
+		if arg != "-" {
+			repos = append(repos, arg)
+			continue
+		}
+
+		identifiers, err := readIdentifiers()
+		if err != nil {
+			return err
+		}
+
+		repos = append(repos, identifiers...)
+	}
+
+	if *fromFile != "" {
+		lines, err := readLines(*fromFile)
+		if err != nil {
+			return err
+		}
+		repos = append(repos, lines...)
+	}
+
+	if len(repos) == 0 {
+		return usageErrorf("provide a repository: repo %s <owner/name>, - reads repositories from stdin", command)
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	client := githubClient()
+
+	send, done := client.Star, "Starred"
+	if command == "unstar" {
+		send, done = client.Unstar, "Unstarred"
+	}
+
+	if command == "unstar" || len(repos) > 1 {
+		subject := fmt.Sprintf("%d repositories", len(repos))
+		if len(repos) == 1 {
+			subject = repos[0]
+		}
+
+		err := confirm(fmt.Sprintf("%s %s?", strings.ToUpper(command[:1])+command[1:], subject), *yes)
+		if err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+
+	// In bulk mode a failure does not stop the remaining repositories.
+	for _, repo := range repos {
+		owner, name, err := parseRepoName(repo)
+		if err == nil {
+			loggerFrom(ctx).Debug("repo "+command, "repo", owner+"/"+name)
+			err = requestError(ctx, send(ctx, owner, name))
+		}
+
+		if errors.Is(err, errDryRun) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", repo, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("%s %s\n", done, repo)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d repositories", command, failed, len(repos))
+	}
+
+	return nil
+}
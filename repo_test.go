@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRepoLicenses(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "test-token")
+
+	withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/repos/octocat/licensed/license":
+			return respondJSON(req, `{"license": {"spdx_id": "MIT", "name": "MIT License"}}`), nil
+		case "/repos/octocat/limited/license":
+			res := respondJSON(req, `{"message": "API rate limit exceeded"}`)
+			res.StatusCode = http.StatusForbidden
+			res.Header.Set("X-RateLimit-Remaining", "0")
+			return res, nil
+		default:
+			res := respondJSON(req, `{"message": "Not Found"}`)
+			res.StatusCode = http.StatusNotFound
+			return res, nil
+		}
+	}))
+
+	out, err := captureStdout(func() error {
+		return executeRepoLicenses(context.Background(), []string{"octocat/licensed", "octocat/unlicensed"})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"octocat/licensed    MIT   MIT License", "octocat/unlicensed  -     -"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output %q does not contain %q", out, want)
+		}
+	}
+
+	_, err = captureStdout(func() error {
+		return executeRepoLicenses(context.Background(), []string{"octocat/licensed", "octocat/limited"})
+	})
+	if exitCode(err) != exitCodes[categoryRateLimited] {
+		t.Errorf("rate limited licenses = %v, want a rate limit error", err)
+	}
+}
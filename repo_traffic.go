@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+type traffic = github.Traffic
+
+type referrer = github.Referrer
+
+func executeRepoTraffic(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("repo traffic", flag.ExitOnError)
+
+	spark := flagSet.Bool("sparkline", false, "show the daily numbers as sparklines instead of a table")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "Repository (owner/name)")
+
+	if len(args) == 0 {
+		return usageError("provide a repository: repo traffic <owner/name>")
+	}
+
+	owner, name, err := parseRepoName(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("repo traffic", "repo", owner+"/"+name)
+
+	views, clones, referrers, err := findTraffic(ctx, owner, name)
+	if errors.Is(err, errForbidden) {
+		return fmt.Errorf("traffic of %s/%s requires push access to the repository", owner, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(map[string]interface{}{
+			"views":     views,
+			"clones":    clones,
+			"referrers": referrers,
+		})
+	}
+
+	fmt.Printf("Views:  %d (%d unique)\n", views.Count, views.Uniques)
+	fmt.Printf("Clones: %d (%d unique)\n\n", clones.Count, clones.Uniques)
+
+	if *spark {
+		fmt.Printf("Views  %s\n", sparkline(trafficCounts(views)))
+		fmt.Printf("Clones %s\n", sparkline(trafficCounts(clones)))
+	} else {
+		err := printTrafficDays(views, clones)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(referrers) == 0 {
+		return nil
+	}
+
+	fmt.Println("\nTop referrers:")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, r := range referrers {
+		fmt.Fprintf(w, "%s\t%d\t%d unique\n", r.Referrer, r.Count, r.Uniques)
+	}
+
+	return w.Flush()
+}
+
+// findTraffic fetches the views, clones and top referrers of the last 14 days.
+func findTraffic(ctx context.Context, owner, name string) (traffic, traffic, []referrer, error) {
+	client := githubClient()
+
+	views, err := client.GetViews(ctx, owner, name)
+	if err != nil {
+		return traffic{}, traffic{}, nil, requestError(ctx, err)
+	}
+
+	clones, err := client.GetClones(ctx, owner, name)
+	if err != nil {
+		return traffic{}, traffic{}, nil, requestError(ctx, err)
+	}
+
+	referrers, err := client.ListReferrers(ctx, owner, name)
+	if err != nil {
+		return traffic{}, traffic{}, nil, requestError(ctx, err)
+	}
+
+	return *views, *clones, referrers, nil
+}
+
+func trafficCounts(t traffic) []int {
+	counts := make([]int, 0, len(t.Days))
+	for _, d := range t.Days {
+		counts = append(counts, d.Count)
+	}
+
+	return counts
+}
+
+func printTrafficDays(views, clones traffic) error {
+	// Days without any traffic are left out by the api.
+	clonesByDay := make(map[string]github.TrafficDay)
+	for _, d := range clones.Days {
+		clonesByDay[formatDate(d.Timestamp)] = d
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DAY\tVIEWS\tVISITORS\tCLONES\tCLONERS")
+
+	seen := make(map[string]bool)
+
+	for _, v := range views.Days {
+		day := formatDate(v.Timestamp)
+		seen[day] = true
+		c := clonesByDay[day]
+
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", day, v.Count, v.Uniques, c.Count, c.Uniques)
+	}
+
+	for _, c := range clones.Days {
+		if day := formatDate(c.Timestamp); !seen[day] {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", day, 0, 0, c.Count, c.Uniques)
+		}
+	}
+
+	return w.Flush()
+}
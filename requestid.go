@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// requestID identifies this invocation in the logs and in the X-Request-Id
+// header of its requests to github, to correlate failures end to end.
+var requestID = newRequestID()
+
+func newRequestID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// serveRequest is a request of a client of serve: its id, taken from the
+// X-Request-Id header of the client or generated, the traceparent it sent
+// and its span when tracing.
+type serveRequest struct {
+	id          string
+	traceparent string
+	span        *span
+}
+
+type serveRequestKey struct{}
+
+func withServeRequest(ctx context.Context, r *serveRequest) context.Context {
+	return context.WithValue(ctx, serveRequestKey{}, r)
+}
+
+func serveRequestFrom(ctx context.Context) *serveRequest {
+	r, _ := ctx.Value(serveRequestKey{}).(*serveRequest)
+	return r
+}
+
+// startServeRequest starts the span of a request of a client, continuing
+// the trace of its traceparent if any. Ids of clients that are too long or
+// could break log lines are replaced.
+func startServeRequest(name, id, traceparent string) *serveRequest {
+	if !validRequestID(id) {
+		id = newRequestID()
+	}
+
+	r := &serveRequest{id: id, traceparent: traceparent}
+
+	if tracing != nil {
+		r.span = tracing.newSpan(name, spanKindServer, nil)
+		r.span.attrs["request.id"] = id
+
+		if traceID, parentID, ok := parseTraceparent(traceparent); ok {
+			r.span.traceID, r.span.parentID = traceID, parentID
+		}
+	}
+
+	return r
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// traceparent returns the w3c traceparent header of the span.
+func (s *span) traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.traceID[:]), hex.EncodeToString(s.spanID[:]))
+}
+
+// activeRequest is the request of a client serve is sending requests to
+// github for. Serve sends them one at a time, see searchServer.fetch, so
+// the transports can tag them with the id and trace of that request.
+var activeRequest struct {
+	mu sync.Mutex
+	r  *serveRequest
+}
+
+func setActiveRequest(r *serveRequest) {
+	activeRequest.mu.Lock()
+	defer activeRequest.mu.Unlock()
+
+	activeRequest.r = r
+}
+
+func getActiveRequest() *serveRequest {
+	activeRequest.mu.Lock()
+	defer activeRequest.mu.Unlock()
+
+	return activeRequest.r
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryTransport retries failed requests -retries times with a growing
+// pause. Requests that change nothing are retried on network errors and on
+// the 502, 503 and 504 github answers when overloaded. Others, like stars,
+// created issues and deletions, are only retried when they failed before
+// anything was sent: github has no idempotency keys, a change that may have
+// reached it could be made twice.
+type retryTransport struct {
+	retries int
+	log     *slog.Logger
+	next    http.RoundTripper
+}
+
+// retryHTTP installs the retry transport on the default http client, in
+// front of the logs, audit and traces so every attempt shows in them.
+func retryHTTP(log *slog.Logger) {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	http.DefaultClient.Transport = &retryTransport{retries: *retries, log: log, next: next}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	safe := !mutating(req)
+
+	for attempt := 0; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+
+		if attempt >= t.retries || !retryable(req, res, err, safe) {
+			return res, err
+		}
+
+		wait := backoff(attempt, res)
+		if wait < 0 {
+			return res, err
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+
+		t.log.Info("retrying request", "method", req.Method, "url", req.URL.String(), "attempt", attempt+1, "wait", wait)
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable reports whether a failed attempt of req may be repeated.
+func retryable(req *http.Request, res *http.Response, err error, safe bool) bool {
+	// The body could not be sent again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		if errors.Is(err, errDeadline) || req.Context().Err() != nil {
+			return false
+		}
+
+		return safe || notSent(err)
+	}
+
+	if !safe {
+		return false
+	}
+
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// notSent reports whether a request failed before any of it got to github:
+// the host did not resolve or the connection was refused.
+func notSent(err error) bool {
+	var dns *net.DNSError
+	if errors.As(err, &dns) {
+		return true
+	}
+
+	var op *net.OpError
+	return errors.As(err, &op) && op.Op == "dial"
+}
+
+// backoff returns how long to wait before the next attempt, 1s, 2s, 4s and
+// so on with some jitter, or as long as github asked with Retry-After. A
+// negative wait gives up, github asked for more than a minute.
+func backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			if seconds > 60 {
+				return -1
+			}
+
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	wait := time.Second << attempt
+
+	return wait + time.Duration(rand.Int63n(int64(wait/4)))
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	dialError = &url.Error{Op: "Post", URL: "https://api.github.com", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	readError = &url.Error{Op: "Post", URL: "https://api.github.com", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+	dnsError  = &url.Error{Op: "Get", URL: "https://api.github.com", Err: &net.DNSError{Err: "no such host", Name: "api.github.com"}}
+)
+
+// testRequest returns a request to the github api, with a body that can be
+// sent again unless body is empty.
+func testRequest(t *testing.T, method, path, body string) *http.Request {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, githubAPI+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return req
+}
+
+func TestRetryable(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		req    func(t *testing.T) *http.Request
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "get dial error", req: get, err: dialError, want: true},
+		{name: "get read error", req: get, err: readError, want: true},
+		{name: "get dns error", req: get, err: dnsError, want: true},
+		{name: "post dial error", req: post, err: dialError, want: true},
+		{name: "post dns error", req: post, err: dnsError, want: true},
+		{name: "post read error", req: post, err: readError, want: false},
+		{name: "delete read error", req: deleteRepo, err: readError, want: false},
+		{name: "delete dial error", req: deleteRepo, err: dialError, want: true},
+		{name: "get 502", req: get, status: http.StatusBadGateway, want: true},
+		{name: "get 503", req: get, status: http.StatusServiceUnavailable, want: true},
+		{name: "get 504", req: get, status: http.StatusGatewayTimeout, want: true},
+		{name: "get 500", req: get, status: http.StatusInternalServerError, want: false},
+		{name: "get 404", req: get, status: http.StatusNotFound, want: false},
+		{name: "get 200", req: get, status: http.StatusOK, want: false},
+		{name: "post 502", req: post, status: http.StatusBadGateway, want: false},
+		{name: "post 503", req: post, status: http.StatusServiceUnavailable, want: false},
+		{name: "delete 504", req: deleteRepo, status: http.StatusGatewayTimeout, want: false},
+		{name: "graphql query 503", req: graphQLQuery, status: http.StatusServiceUnavailable, want: true},
+		{name: "graphql mutation 503", req: graphQLMutation, status: http.StatusServiceUnavailable, want: false},
+		{name: "body without GetBody dial error", req: withoutGetBody(post), err: dialError, want: false},
+		{name: "body without GetBody 503", req: withoutGetBody(graphQLQuery), status: http.StatusServiceUnavailable, want: false},
+		{name: "deadline", req: get, err: errDeadline, want: false},
+		{name: "cancelled", req: func(t *testing.T) *http.Request { return get(t).WithContext(cancelled) }, err: dialError, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req(t)
+
+			var res *http.Response
+			if tt.err == nil {
+				res = &http.Response{StatusCode: tt.status, Header: http.Header{}, Body: http.NoBody, Request: req}
+			}
+
+			if got := retryable(req, res, tt.err, !mutating(req)); got != tt.want {
+				t.Errorf("retryable = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func get(t *testing.T) *http.Request {
+	return testRequest(t, http.MethodGet, "/repos/golang/go", "")
+}
+
+func post(t *testing.T) *http.Request {
+	return testRequest(t, http.MethodPost, "/repos/golang/go/issues", `{"title":"crash"}`)
+}
+
+func deleteRepo(t *testing.T) *http.Request {
+	return testRequest(t, http.MethodDelete, "/repos/octo/old", "")
+}
+
+func graphQLQuery(t *testing.T) *http.Request {
+	return testRequest(t, http.MethodPost, "/graphql", `{"query":"query { viewer { login } }"}`)
+}
+
+func graphQLMutation(t *testing.T) *http.Request {
+	return testRequest(t, http.MethodPost, "/graphql", `{"query":"mutation { addStar(input: {starrableId: \"1\"}) { clientMutationId } }"}`)
+}
+
+// withoutGetBody returns the request of newRequest with a body that can only
+// be read once, like a streamed file.
+func withoutGetBody(newRequest func(t *testing.T) *http.Request) func(t *testing.T) *http.Request {
+	return func(t *testing.T) *http.Request {
+		req := newRequest(t)
+		req.Body = io.NopCloser(req.Body)
+		req.GetBody = nil
+
+		return req
+	}
+}
+
+func TestNotSent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "dial", err: dialError, want: true},
+		{name: "dns", err: dnsError, want: true},
+		{name: "read", err: readError, want: false},
+		{name: "write", err: &net.OpError{Op: "write", Net: "tcp", Err: errors.New("broken pipe")}, want: false},
+		{name: "eof", err: io.ErrUnexpectedEOF, want: false},
+		{name: "timeout", err: context.DeadlineExceeded, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notSent(tt.err); got != tt.want {
+				t.Errorf("notSent = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "first", attempt: 0, min: time.Second, max: 1250 * time.Millisecond},
+		{name: "second", attempt: 1, min: 2 * time.Second, max: 2500 * time.Millisecond},
+		{name: "third", attempt: 2, min: 4 * time.Second, max: 5 * time.Second},
+		{name: "retry after", attempt: 2, retryAfter: "7", min: 7 * time.Second, max: 7 * time.Second},
+		{name: "retry after zero", attempt: 1, retryAfter: "0", min: 0, max: 0},
+		{name: "retry after a minute", attempt: 0, retryAfter: "60", min: time.Minute, max: time.Minute},
+		{name: "retry after over a minute", attempt: 0, retryAfter: "61", min: -1, max: -1},
+		{name: "retry after a date", attempt: 0, retryAfter: "Wed, 21 Oct 2026 07:28:00 GMT", min: time.Second, max: 1250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				res.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			if got := backoff(tt.attempt, res); got < tt.min || got > tt.max {
+				t.Errorf("backoff = %s, want between %s and %s", got, tt.min, tt.max)
+			}
+		})
+	}
+
+	// Network errors have no response.
+	if got := backoff(0, nil); got < time.Second || got > 1250*time.Millisecond {
+		t.Errorf("backoff without response = %s", got)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      func(t *testing.T) *http.Request
+		statuses []int
+		errs     []error
+		attempts int
+	}{
+		{name: "get overloaded", req: get, statuses: []int{503, 502, 200}, attempts: 3},
+		{name: "get overloaded too often", req: get, statuses: []int{503, 503, 503, 503}, attempts: 3},
+		{name: "post overloaded", req: post, statuses: []int{503, 200}, attempts: 1},
+		{name: "post refused", req: post, errs: []error{dialError}, statuses: []int{0, 201}, attempts: 2},
+		{name: "post reset", req: post, errs: []error{readError}, statuses: []int{0, 201}, attempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies := make([]string, 0)
+
+			next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				attempt := len(bodies)
+
+				body := ""
+				if req.Body != nil {
+					data, _ := io.ReadAll(req.Body)
+					body = string(data)
+				}
+				bodies = append(bodies, body)
+
+				if attempt < len(tt.errs) && tt.errs[attempt] != nil {
+					return nil, tt.errs[attempt]
+				}
+
+				// Retry right away.
+				header := http.Header{"Retry-After": {"0"}}
+
+				return &http.Response{StatusCode: tt.statuses[attempt], Header: header, Body: http.NoBody, Request: req}, nil
+			})
+
+			req := tt.req(t)
+			want := ""
+			if req.GetBody != nil {
+				body, _ := req.GetBody()
+				data, _ := io.ReadAll(body)
+				want = string(data)
+			}
+
+			transport := &retryTransport{retries: 2, log: loggerFrom(context.Background()), next: next}
+			transport.RoundTrip(req)
+
+			if len(bodies) != tt.attempts {
+				t.Fatalf("attempts = %d, want %d", len(bodies), tt.attempts)
+			}
+
+			for i, body := range bodies {
+				if body != want {
+					t.Errorf("body of attempt %d = %q, want %q", i+1, body, want)
+				}
+			}
+		})
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// rpcToolError is the code of responses to requests whose tool failed, like
+// a search github rejected.
+const rpcToolError = -32000
+
+// serveRPC answers newline delimited JSON-RPC 2.0 requests on stdin with
+// response lines on stdout, one at a time and in order, until stdin is
+// closed. The methods are the tools, e.g.
+//
+//	{"jsonrpc":"2.0","id":1,"method":"search_repos","params":{"query":"cli","limit":5}}
+func serveRPC(ctx context.Context) error {
+	// Stdout carries the responses.
+	*quiet = true
+
+	return serveJSONRPC(ctx, os.Stdin, os.Stdout, handleRPC)
+}
+
+func handleRPC(ctx context.Context, method string, params json.RawMessage) (interface{}, *rpcError) {
+	loggerFrom(ctx).Debug("rpc", "method", method)
+
+	args := toolArguments{}
+
+	if err := decodeParams(params, &args); err != nil {
+		return nil, err
+	}
+
+	result, found, err := callTool(ctx, method, args)
+	if !found {
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method '%s' not found", method)}
+	}
+
+	if err != nil {
+		return nil, &rpcError{Code: rpcToolError, Message: err.Error()}
+	}
+
+	return result, nil
+}
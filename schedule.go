@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"math/rand"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+var scheduleUsage = `Usage: schedule '<cron expression>' [flags] -- <command> [args]
+
+Runs the command, like search-repos topic:llm --diff llm --save llm --notify,
+every time the cron expression matches, until interrupted. With --unit it
+prints a systemd or launchd unit running the schedule as a service instead.`
+
+const launchdLabel = "com.github.gurleensethi.go-cli-flag.schedule"
+
+func executeSchedule(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("schedule", flag.ExitOnError)
+
+	jitter := flagSet.Duration("jitter", 30*time.Second, "delay every run by a random duration up to this, so schedules on many machines do not hit github at once")
+	backoff := flagSet.Duration("backoff", time.Minute, "wait at least this long after a failed run, doubled on every failure in a row")
+	maxBackoff := flagSet.Duration("max-backoff", time.Hour, "longest wait after failed runs")
+	unit := flagSet.String("unit", "", "print a systemd or launchd unit running the schedule instead of running it")
+
+	// Everything after -- belongs to the scheduled command.
+	var command []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, command = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) == 0 {
+		return usageError(scheduleUsage)
+	}
+
+	expr := args[0]
+	command = append(args[1:], command...)
+
+	loggerFrom(ctx).Debug("schedule", "expression", expr, "command", command, "jitter", *jitter, "unit", *unit)
+
+	if len(command) == 0 {
+		return usageError(scheduleUsage)
+	}
+
+	if command[0] == "schedule" {
+		return errors.New("a schedule cannot run another schedule")
+	}
+
+	schedule, err := parseCron(expr)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := schedule.next(time.Now()); !ok {
+		return fmt.Errorf("cron expression '%s' never matches", expr)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// The top level flags, like -debug, apply to every run.
+	topLevel := os.Args[1 : len(os.Args)-len(flag.Args())]
+	runArgs := append(append([]string{}, topLevel...), command...)
+
+	switch *unit {
+	case "":
+		return runSchedule(ctx, schedule, exe, runArgs, *jitter, *backoff, *maxBackoff)
+	case "systemd", "launchd":
+		serviceArgs := append(append([]string{}, topLevel...), "schedule", expr,
+			"--jitter", jitter.String(), "--backoff", backoff.String(), "--max-backoff", maxBackoff.String(), "--")
+		serviceArgs = append(serviceArgs, command...)
+
+		if *unit == "systemd" {
+			fmt.Print(systemdUnit(exe, serviceArgs, expr))
+			fmt.Fprintln(os.Stderr, "Save as ~/.config/systemd/user/gcf-schedule.service and start with: systemctl --user enable --now gcf-schedule")
+		} else {
+			fmt.Print(launchdPlist(exe, serviceArgs))
+			fmt.Fprintf(os.Stderr, "Save as ~/Library/LaunchAgents/%s.plist and start with: launchctl load ~/Library/LaunchAgents/%s.plist\n", launchdLabel, launchdLabel)
+		}
+
+		return nil
+	default:
+		return usageErrorf("invalid unit '%s', use systemd or launchd", *unit)
+	}
+}
+
+// runSchedule runs the command as a child process every time the schedule
+// matches, so a failing run, or one exiting on a bad flag, does not end the
+// schedule. After failures later runs are skipped until the backoff passed.
+func runSchedule(ctx context.Context, schedule *cronSchedule, exe string, args []string, jitter, backoff, maxBackoff time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	failures := 0
+	after := time.Now()
+
+	for {
+		next, ok := schedule.next(after)
+		if !ok {
+			return errors.New("the cron expression does not match again")
+		}
+
+		if jitter > 0 {
+			next = next.Add(time.Duration(rand.Int63n(int64(jitter))))
+		}
+
+		fmt.Fprintf(os.Stderr, "Next run at %s\n", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		start := time.Now()
+
+		cmd := exec.CommandContext(ctx, exe, args...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		// Let an interrupted run finish writing before it is killed.
+		cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+		cmd.WaitDelay = 10 * time.Second
+
+		err := cmd.Run()
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		after = time.Now()
+
+		if err == nil {
+			failures = 0
+			loggerFrom(ctx).Debug("schedule: run finished", "duration", time.Since(start).Round(time.Millisecond))
+			continue
+		}
+
+		failures++
+
+		wait := backoff << (failures - 1)
+		if wait > maxBackoff || wait <= 0 {
+			wait = maxBackoff
+		}
+
+		loggerFrom(ctx).Warn("schedule: run failed", "err", err, "failures", failures, "wait", wait)
+
+		after = after.Add(wait)
+	}
+}
+
+// systemdArg quotes an argument for ExecStart, escaping what systemd would
+// expand.
+func systemdArg(arg string) string {
+	arg = strings.ReplaceAll(arg, "%", "%%")
+	arg = strings.ReplaceAll(arg, "$", "$$")
+
+	if arg != "" && !strings.ContainsAny(arg, " \t\"'\\;") {
+		return arg
+	}
+
+	arg = strings.ReplaceAll(arg, `\`, `\\`)
+	arg = strings.ReplaceAll(arg, `"`, `\"`)
+
+	return `"` + arg + `"`
+}
+
+func systemdUnit(exe string, args []string, expr string) string {
+	line := make([]string, 0, len(args)+1)
+	line = append(line, systemdArg(exe))
+	for _, arg := range args {
+		line = append(line, systemdArg(arg))
+	}
+
+	return fmt.Sprintf(`[Unit]
+Description=go-cli-flag schedule %s
+After=network-online.target
+
+[Service]
+Type=simple
+ExecStart=%s
+Restart=on-failure
+RestartSec=30
+# Commands that need authentication read GITHUB_TOKEN, e.g.
+# Environment=GITHUB_TOKEN=...
+
+[Install]
+WantedBy=default.target
+`, strings.ReplaceAll(expr, "%", "%%"), strings.Join(line, " "))
+}
+
+func launchdPlist(exe string, args []string) string {
+	var arguments strings.Builder
+	for _, arg := range append([]string{exe}, args...) {
+		fmt.Fprintf(&arguments, "\t\t<string>%s</string>\n", html.EscapeString(arg))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>/tmp/%s.out.log</string>
+	<key>StandardErrorPath</key>
+	<string>/tmp/%s.err.log</string>
+</dict>
+</plist>
+`, launchdLabel, arguments.String(), launchdLabel, launchdLabel)
+}
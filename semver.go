@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version, see https://semver.org.
+type semver struct {
+	major, minor, patch int
+	prerelease          []string
+}
+
+// parseSemver parses a version tag like v1.2.3 or 1.2.3-rc.1+build. The "v"
+// prefix is optional, build metadata is ignored as it has no precedence.
+func parseSemver(tag string) (semver, bool) {
+	v := strings.TrimPrefix(tag, "v")
+
+	if i := strings.Index(v, "+"); i >= 0 {
+		v = v[:i]
+	}
+
+	version := semver{}
+
+	if i := strings.Index(v, "-"); i >= 0 {
+		version.prerelease = strings.Split(v[i+1:], ".")
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return semver{}, false
+	}
+
+	numbers := make([]int, 3)
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		numbers[i] = n
+	}
+
+	version.major, version.minor, version.patch = numbers[0], numbers[1], numbers[2]
+
+	return version, true
+}
+
+// compare returns -1, 0 or 1 when v has lower, equal or higher precedence than other.
+func (v semver) compare(other semver) int {
+	if c := compareInt(v.major, other.major); c != 0 {
+		return c
+	}
+
+	if c := compareInt(v.minor, other.minor); c != 0 {
+		return c
+	}
+
+	if c := compareInt(v.patch, other.patch); c != 0 {
+		return c
+	}
+
+	// A pre-release version has a lower precedence than the release.
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		if c := comparePrerelease(v.prerelease[i], other.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInt(len(v.prerelease), len(other.prerelease))
+}
+
+// comparePrerelease compares pre-release identifiers, numeric identifiers
+// compare numerically and always have lower precedence than alphanumeric ones.
+func comparePrerelease(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return compareInt(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want semver
+		ok   bool
+	}{
+		{tag: "v1.2.3", want: semver{major: 1, minor: 2, patch: 3}, ok: true},
+		{tag: "1.2.3", want: semver{major: 1, minor: 2, patch: 3}, ok: true},
+		{tag: "v0.10.0", want: semver{minor: 10}, ok: true},
+		{tag: "v1.2.3-rc.1", want: semver{major: 1, minor: 2, patch: 3, prerelease: []string{"rc", "1"}}, ok: true},
+		{tag: "1.2.3-rc.1+build.5", want: semver{major: 1, minor: 2, patch: 3, prerelease: []string{"rc", "1"}}, ok: true},
+		{tag: "1.2.3+build", want: semver{major: 1, minor: 2, patch: 3}, ok: true},
+		{tag: "v1.2"},
+		{tag: "v1.2.3.4"},
+		{tag: "v1.x.3"},
+		{tag: "latest"},
+		{tag: "release-2026"},
+		{tag: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := parseSemver(tt.tag)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSemver(%q) = %+v, %t, want %+v, %t", tt.tag, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// In order of precedence, the example of semver.org and more.
+	ordered := []string{
+		"0.9.9",
+		"v1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i, a := range ordered {
+		for j, b := range ordered {
+			va, _ := parseSemver(a)
+			vb, _ := parseSemver(b)
+
+			want := compareInt(i, j)
+			if got := va.compare(vb); got != want {
+				t.Errorf("%s compared to %s = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	// Build metadata has no precedence.
+	a, _ := parseSemver("1.0.0+20260101")
+	b, _ := parseSemver("v1.0.0+exp.sha.5114f85")
+	if c := a.compare(b); c != 0 {
+		t.Errorf("versions differing in build metadata compare to %d", c)
+	}
+}
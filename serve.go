@@ -0,0 +1,395 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// searchServer serves the search commands over http. Responses are cached
+// for a while and the searches sent to github are capped per minute, the
+// search api allows 30 a minute with a token and 10 without.
+type searchServer struct {
+	apiKey   string
+	cacheTTL time.Duration
+	rate     int
+	log      *slog.Logger
+
+	mu      sync.Mutex
+	cache   map[string]cachedResponse
+	window  time.Time
+	fetches int
+
+	// fetchMu sends the searches to github one at a time.
+	fetchMu sync.Mutex
+}
+
+type cachedResponse struct {
+	body    []byte
+	expires time.Time
+}
+
+func newSearchServer(log *slog.Logger, apiKey string, cacheTTL time.Duration, rate int) *searchServer {
+	return &searchServer{
+		apiKey:   apiKey,
+		cacheTTL: cacheTTL,
+		rate:     rate,
+		log:      log,
+		cache:    make(map[string]cachedResponse),
+	}
+}
+
+func executeServe(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("serve", flag.ExitOnError)
+
+	addr := flagSet.String("addr", ":8080", "address to listen on")
+	apiKey := flagSet.String("api-key", os.Getenv("GCF_SERVE_KEY"), "key clients send as a bearer token, defaults to GCF_SERVE_KEY, empty allows every client")
+	cacheTTL := flagSet.Duration("cache-ttl", time.Minute, "how long search results are served from the cache, 0 turns the cache off")
+	rate := flagSet.Int("rate", 30, "maximum number of searches sent to github per minute")
+	grpcAddr := flagSet.String("grpc", "", "also serve the grpc api of searchpb/search.proto on an address, e.g. :9090")
+	mcp := flagSet.Bool("mcp", false, "serve the searches as model context protocol tools on stdin and stdout instead")
+
+	parseArgs(flagSet, args)
+
+	if *mcp {
+		return serveMCP(ctx)
+	}
+
+	loggerFrom(ctx).Debug("serve", "addr", *addr, "grpc", *grpcAddr, "cache_ttl", *cacheTTL, "rate", *rate)
+
+	if *addr == "" && *grpcAddr == "" {
+		return usageError("provide an address to serve on: serve --addr :8080 | --grpc :9090")
+	}
+
+	// Spinners and progress bars make no sense for requests of clients.
+	*quiet = true
+
+	instrumentHTTP()
+
+	s := newSearchServer(loggerFrom(ctx), *apiKey, *cacheTTL, *rate)
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	grpcServer := s.newGRPCServer()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	// The first server to fail stops the other one, interrupting stops both.
+	errs := make(chan error, 2)
+
+	if *addr != "" {
+		listener, err := net.Listen("tcp", *addr)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Serving on %s\n", listener.Addr())
+
+		go func() {
+			errs <- server.Serve(listener)
+		}()
+	}
+
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Serving grpc on %s\n", listener.Addr())
+
+		go func() {
+			errs <- grpcServer.Serve(listener)
+		}()
+	}
+
+	var err error
+
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	server.Shutdown(shutdown)
+	grpcServer.GracefulStop()
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (s *searchServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/repos", s.searchHandler(func(ctx context.Context, term, sort string) (interface{}, error) {
+		return searchRepositories(ctx, term, sort)
+	}))
+
+	mux.HandleFunc("/api/users", s.searchHandler(func(ctx context.Context, term, sort string) (interface{}, error) {
+		logins, err := findUsers(ctx, term, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		users := make([]userSummary, len(logins))
+		for i, login := range logins {
+			users[i] = userSummary{Login: login, HTMLURL: "https://github.com/" + login}
+		}
+
+		return users, nil
+	}))
+
+	// Scrapers do not send the api key.
+	root := http.NewServeMux()
+	root.HandleFunc("/metrics", metricsHandler)
+	root.Handle("/", s.authenticate(mux))
+
+	return s.logRequests(root)
+}
+
+// logRequests prints a line per request to stderr with its request id and
+// counts it in the metrics. The request is handled with the logger of the
+// server. Unknown paths share a handler label to bound the
+// series. The id is answered in the X-Request-Id header.
+func (s *searchServer) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		sr := startServeRequest(r.Method+" "+r.URL.Path, r.Header.Get("X-Request-Id"), r.Header.Get("traceparent"))
+		w.Header().Set("X-Request-Id", sr.id)
+
+		next.ServeHTTP(rec, r.WithContext(withServeRequest(withLogger(r.Context(), s.log), sr)))
+
+		fmt.Fprintf(os.Stderr, "%s %s %s %d %s %s\n", start.Format(time.RFC3339), r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond), sr.id)
+
+		if sr.span != nil {
+			sr.span.attrs["http.request.method"] = r.Method
+			sr.span.attrs["url.path"] = r.URL.Path
+			sr.span.attrs["http.response.status_code"] = rec.status
+
+			var err error
+			if rec.status >= 500 {
+				err = errors.New(http.StatusText(rec.status))
+			}
+			tracing.end(sr.span, err)
+		}
+
+		handler := "other"
+		if r.URL.Path == "/api/repos" || r.URL.Path == "/api/users" || r.URL.Path == "/metrics" {
+			handler = r.URL.Path
+		}
+
+		metrics.inc("gcf_requests_total", "api", "http", "handler", handler, "code", strconv.Itoa(rec.status))
+		metrics.observe("gcf_request_duration_seconds", time.Since(start), "api", "http", "handler", handler)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// authenticate requires the api key as a bearer token when one is set.
+func (s *searchServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r.Header.Values("Authorization")) {
+			writeJSONError(w, http.StatusUnauthorized, "missing or invalid api key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorized reports whether one of the authorization values carries the
+// api key as a bearer token, or no key is set. The key is compared in
+// constant time, so the time of a rejection tells nothing about it.
+func (s *searchServer) authorized(values []string) bool {
+	if s.apiKey == "" {
+		return true
+	}
+
+	ok := false
+	for _, value := range values {
+		if subtle.ConstantTimeCompare([]byte(value), []byte("Bearer "+s.apiKey)) == 1 {
+			ok = true
+		}
+	}
+
+	return ok
+}
+
+// searchHandler answers GET requests with the results of search for the q
+// and sort query parameters, wrapped with the query in an object.
+func (s *searchServer) searchHandler(search func(ctx context.Context, term, sort string) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeJSONError(w, http.StatusMethodNotAllowed, "only GET is supported")
+			return
+		}
+
+		term := r.URL.Query().Get("q")
+		sort := r.URL.Query().Get("sort")
+
+		if term == "" {
+			writeJSONError(w, http.StatusBadRequest, "provide a search term with the q parameter")
+			return
+		}
+
+		key := r.URL.Path + "?" + r.URL.Query().Encode()
+
+		if body, ok := s.cached(key); ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Cache", "hit")
+			w.Write(body)
+			return
+		}
+
+		if wait, ok := s.allowFetch(); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			writeJSONError(w, http.StatusTooManyRequests, "too many searches, try again later")
+			return
+		}
+
+		var items interface{}
+
+		err := s.fetch(r.Context(), func() error {
+			var err error
+			items, err = search(r.Context(), term, sort)
+			return err
+		})
+		if err != nil {
+			writeJSONError(w, errorStatus(err), err.Error())
+			return
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"query": term,
+			"sort":  sort,
+			"items": items,
+		})
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		s.store(key, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Cache", "miss")
+		w.Write(body)
+	}
+}
+
+func (s *searchServer) cached(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(s.cache, key)
+		recordCacheLookup(false)
+		return nil, false
+	}
+
+	recordCacheLookup(true)
+
+	return entry.body, true
+}
+
+func (s *searchServer) store(key string, body []byte) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cache[key] = cachedResponse{body: body, expires: time.Now().Add(s.cacheTTL)}
+}
+
+// fetch runs a search for the request of ctx. Searches are sent to github
+// one at a time, which costs little as the search api allows 30 a minute, so
+// their requests carry the id and the trace of the request they are for.
+func (s *searchServer) fetch(ctx context.Context, search func() error) error {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	setActiveRequest(serveRequestFrom(ctx))
+	defer setActiveRequest(nil)
+
+	return search()
+}
+
+// allowFetch counts a search sent to github in the current minute, or
+// returns how long to wait when the rate is used up.
+func (s *searchServer) allowFetch() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(s.window) >= time.Minute {
+		s.window = now
+		s.fetches = 0
+	}
+
+	if s.rate > 0 && s.fetches >= s.rate {
+		return s.window.Add(time.Minute).Sub(now), false
+	}
+
+	s.fetches++
+
+	return 0, true
+}
+
+// errorStatus maps the errors of the github requests to a status code.
+func errorStatus(err error) int {
+	switch {
+	case errorCategory(err) == categoryRateLimited:
+		return http.StatusTooManyRequests
+	case errors.Is(err, errNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errOffline):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
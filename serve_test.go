@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name   string
+		key    string
+		header []string
+		status int
+	}{
+		{name: "no key", status: http.StatusOK},
+		{name: "no key with a token", header: []string{"Bearer anything"}, status: http.StatusOK},
+		{name: "key", key: "s3cr3t-key", header: []string{"Bearer s3cr3t-key"}, status: http.StatusOK},
+		{name: "key among others", key: "s3cr3t-key", header: []string{"Basic b2N0bw==", "Bearer s3cr3t-key"}, status: http.StatusOK},
+		{name: "missing", key: "s3cr3t-key", status: http.StatusUnauthorized},
+		{name: "wrong", key: "s3cr3t-key", header: []string{"Bearer s3cr3t-kez"}, status: http.StatusUnauthorized},
+		{name: "prefix", key: "s3cr3t-key", header: []string{"Bearer s3cr3t"}, status: http.StatusUnauthorized},
+		{name: "longer", key: "s3cr3t-key", header: []string{"Bearer s3cr3t-key2"}, status: http.StatusUnauthorized},
+		{name: "without bearer", key: "s3cr3t-key", header: []string{"s3cr3t-key"}, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSearchServer(nil, tt.key, time.Minute, 0)
+
+			req := httptest.NewRequest(http.MethodGet, "/search/repos?q=cli", nil)
+			for _, value := range tt.header {
+				req.Header.Add("Authorization", value)
+			}
+
+			rec := httptest.NewRecorder()
+			s.authenticate(ok).ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// sinceOptions holds the --since flags of the search commands.
+type sinceOptions struct {
+	since string
+	field string
+}
+
+// addSinceFlags registers the --since and --since-field flags, fields lists
+// the date qualifiers of the search, the first one is the default.
+func addSinceFlags(flagSet *flag.FlagSet, fields ...string) *sinceOptions {
+	opts := &sinceOptions{}
+
+	flagSet.StringVar(&opts.since, "since", "", "only find results since a time: a date, an RFC 3339 timestamp, a duration like 24h or last for the last successful run of the same search")
+	if len(fields) > 1 {
+		flagSet.StringVar(&opts.field, "since-field", fields[0], "date qualifier --since applies to: "+fmt.Sprint(fields))
+	} else {
+		opts.field = fields[0]
+	}
+
+	return opts
+}
+
+func runsPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "runs.json"), nil
+}
+
+// loadRuns reads the start times of the last successful run of each search,
+// keyed by the command and search term.
+func loadRuns() (map[string]time.Time, error) {
+	runs := make(map[string]time.Time)
+
+	path, err := runsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return runs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &runs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid runs file %s: %v", path, err)
+	}
+
+	return runs, nil
+}
+
+// recordRun saves started as the last successful run of the search. Like the
+// history failing to save is only reported in the debug output.
+func recordRun(ctx context.Context, key string, started time.Time) {
+	path, err := runsPath()
+	if err != nil {
+		loggerFrom(ctx).Debug("since", "err", err)
+		return
+	}
+
+	runs, err := loadRuns()
+	if err == nil {
+		runs[key] = started.UTC().Truncate(time.Second)
+
+		var data []byte
+		data, err = json.MarshalIndent(runs, "", "  ")
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(path), 0o700)
+		}
+		if err == nil {
+			err = os.WriteFile(path+".tmp", data, 0o600)
+		}
+		if err == nil {
+			err = os.Rename(path+".tmp", path)
+		}
+	}
+	if err != nil {
+		loggerFrom(ctx).Debug("since", "err", err)
+	}
+}
+
+// apply adds the date qualifier for --since to the search term of the
+// search identified by key. Without a recorded run, since last searches
+// everything, so the first run of a scheduled job processes all results.
+func (o *sinceOptions) apply(ctx context.Context, key, term string) (string, error) {
+	if o.since == "" {
+		return term, nil
+	}
+
+	var since time.Time
+
+	if o.since == "last" {
+		runs, err := loadRuns()
+		if err != nil {
+			return "", err
+		}
+
+		last, ok := runs[key]
+		if !ok {
+			fmt.Fprintln(os.Stderr, "No previous run of this search, searching everything")
+			return term, nil
+		}
+
+		since = last
+	} else if d, err := time.ParseDuration(o.since); err == nil {
+		since = time.Now().Add(-d)
+	} else if t, err := time.Parse(time.RFC3339, o.since); err == nil {
+		since = t
+	} else if t, err := time.Parse("2006-01-02", o.since); err == nil {
+		since = t
+	} else {
+		return "", usageErrorf("invalid --since '%s', expected a date, an RFC 3339 timestamp, a duration or last", o.since)
+	}
+
+	qualified := fmt.Sprintf("%s %s:>=%s", term, o.field, since.UTC().Truncate(time.Second).Format(time.RFC3339))
+
+	loggerFrom(ctx).Debug("since", "query", qualified)
+
+	return qualified, nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// searchSnapshot is a saved run of search-repos to diff later runs against.
+type searchSnapshot struct {
+	Term         string       `json:"term"`
+	Sort         string       `json:"sort,omitempty"`
+	SavedAt      time.Time    `json:"saved_at"`
+	Repositories []repository `json:"repositories"`
+}
+
+var snapshotName = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func snapshotPath(name string) (string, error) {
+	if !snapshotName.MatchString(name) {
+		return "", usageErrorf("invalid snapshot name '%s', use letters, digits, '.', '-' and '_'", name)
+	}
+
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "snapshots", name+".json"), nil
+}
+
+func loadSnapshot(name string) (searchSnapshot, error) {
+	snapshot := searchSnapshot{}
+
+	path, err := snapshotPath(name)
+	if err != nil {
+		return snapshot, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return snapshot, fmt.Errorf("no snapshot named '%s', save one with --save %s", name, name)
+	}
+	if err != nil {
+		return snapshot, err
+	}
+
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return snapshot, fmt.Errorf("invalid snapshot file %s: %v", path, err)
+	}
+
+	return snapshot, nil
+}
+
+// saveSnapshot writes the snapshot to a temporary file first so an
+// interrupted write does not lose the previous one.
+func saveSnapshot(name string, snapshot searchSnapshot) error {
+	path, err := snapshotPath(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path+".tmp", data, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
+
+// diffSearchRepos runs the search and prints what changed since the snapshot
+// named diff, then saves the results as the snapshot named save. Either name
+// may be empty. Diffing and saving the same name moves the baseline forward.
+// With notify the repositories added since the snapshot also show up as a
+// desktop notification, and are posted to the alert url if there is one.
+func diffSearchRepos(ctx context.Context, term, sort, save, diff string, notify bool, alert *alerter) error {
+	var baseline searchSnapshot
+
+	if diff != "" {
+		var err error
+		baseline, err = loadSnapshot(diff)
+		if err != nil {
+			return err
+		}
+	}
+
+	if save != "" {
+		// Fail on a bad name before running the search.
+		_, err := snapshotPath(save)
+		if err != nil {
+			return err
+		}
+	}
+
+	repos, err := searchRepositories(ctx, term, sort)
+	if err != nil {
+		return err
+	}
+
+	if diff != "" {
+		if baseline.Term != term || baseline.Sort != sort {
+			loggerFrom(ctx).Warn("the snapshot was saved for another search", "snapshot", diff, "saved_for", baseline.Term, "term", term)
+		}
+
+		changes := diffRepositories(baseline.Repositories, repos, time.Now())
+
+		if !jsonOutput() {
+			fmt.Fprintf(os.Stderr, "%d changes since %s (%s)\n", len(changes), diff, baseline.SavedAt.Local().Format("2006-01-02 15:04"))
+		}
+
+		for _, c := range changes {
+			err := printRepositoryChange(c)
+			if err != nil {
+				return err
+			}
+		}
+
+		if notify {
+			notifyNewRepositories(ctx, term, changes)
+		}
+
+		alert.send(ctx, term, changes)
+	}
+
+	if save == "" {
+		return nil
+	}
+
+	err = saveSnapshot(save, searchSnapshot{Term: term, Sort: sort, SavedAt: time.Now(), Repositories: repos})
+	if err != nil {
+		loggerFrom(ctx).Debug("saving the snapshot failed", "err", err)
+		return errors.New("failed to save the snapshot")
+	}
+
+	if !jsonOutput() {
+		fmt.Fprintf(os.Stderr, "Saved %d repositories as %s\n", len(repos), save)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnersPaused is set while a full screen view or another status line
+// owns the terminal.
+var spinnersPaused bool
+
+// spinner animates on stderr while a request is in flight. It only shows
+// up when the request takes a noticeable time so fast requests do not
+// flicker.
+type spinner struct {
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// showActivity reports whether spinners and other transient status lines
+// should be drawn on stderr. They stay silent when the output is piped, in
+// quiet mode and with debug output as the lines would mix.
+func showActivity() bool {
+	return !*quiet && !*debug && !spinnersPaused && isTerminal(os.Stderr) && isTerminal(os.Stdout)
+}
+
+// startSpinner starts a spinner labeled with label.
+func startSpinner(label string) *spinner {
+	if !showActivity() {
+		return nil
+	}
+
+	s := &spinner{stop: make(chan struct{})}
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		start := time.Now()
+
+		select {
+		case <-time.After(200 * time.Millisecond):
+		case <-s.stop:
+			return
+		}
+
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for i := 0; ; i++ {
+			fmt.Fprintf(os.Stderr, "\r%s %s %.1fs", spinnerFrames[i%len(spinnerFrames)], label, time.Since(start).Seconds())
+
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				fmt.Fprint(os.Stderr, "\r\033[K")
+				return
+			}
+		}
+	}()
+
+	return s
+}
+
+// Stop stops the spinner and clears its line before results are printed.
+func (s *spinner) Stop() {
+	if s == nil {
+		return
+	}
+
+	close(s.stop)
+	s.wg.Wait()
+}
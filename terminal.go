@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// terminal is the controlling terminal switched to raw mode, so single key
+// presses can be read without waiting for enter. Raw mode is set with stty
+// to avoid platform specific ioctls.
+type terminal struct {
+	tty   *os.File
+	saved string
+	log   *slog.Logger
+	stop  func() bool
+}
+
+func openTerminal(ctx context.Context) (*terminal, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		loggerFrom(ctx).Debug("opening the terminal failed", "err", err)
+		return nil, errors.New("interactive mode needs a terminal")
+	}
+
+	t := &terminal{tty: tty, log: loggerFrom(ctx)}
+
+	t.saved, err = t.stty("-g")
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	_, err = t.stty("raw", "-echo")
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	// Switch to the alternate screen so the shell is left as it was.
+	fmt.Fprint(tty, "\033[?1049h\033[?25l")
+	spinnersPaused = true
+
+	// A key press being waited for when ctx is done, e.g. at the -deadline,
+	// fails so the caller returns and the terminal is restored.
+	t.stop = context.AfterFunc(ctx, func() {
+		tty.SetReadDeadline(time.Now())
+	})
+
+	return t, nil
+}
+
+func (t *terminal) stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = t.tty
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.log.Debug("terminal: stty failed", "args", args, "err", err)
+		return "", errors.New("interactive mode needs a terminal")
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Close restores the screen and the terminal settings.
+func (t *terminal) Close() {
+	t.stop()
+	spinnersPaused = false
+	fmt.Fprint(t.tty, "\033[?25h\033[?1049l")
+	t.stty(t.saved)
+	t.tty.Close()
+}
+
+// size returns the number of rows and columns of the terminal.
+func (t *terminal) size() (int, int) {
+	rows, cols := 24, 80
+
+	out, err := t.stty("size")
+	if err == nil {
+		fmt.Sscanf(out, "%d %d", &rows, &cols)
+	}
+
+	return rows, cols
+}
+
+// readKey reads a key press, escape sequences of the arrow and page keys are
+// returned as names like "up" and "pgdown".
+func (t *terminal) readKey() (string, error) {
+	buf := make([]byte, 8)
+
+	n, err := t.tty.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	key := string(buf[:n])
+
+	switch key {
+	case "\033[A", "\033OA":
+		return "up", nil
+	case "\033[B", "\033OB":
+		return "down", nil
+	case "\033[5~":
+		return "pgup", nil
+	case "\033[6~":
+		return "pgdown", nil
+	case "\r", "\n":
+		return "enter", nil
+	case "\033":
+		return "esc", nil
+	case "\x03":
+		return "ctrl-c", nil
+	case "\x7f", "\b":
+		return "backspace", nil
+	}
+
+	return key, nil
+}
+
+// draw replaces the screen with lines, raw mode needs explicit carriage
+// returns.
+func (t *terminal) draw(lines []string) {
+	var b strings.Builder
+
+	b.WriteString("\033[H\033[2J")
+	b.WriteString(strings.Join(lines, "\r\n"))
+
+	fmt.Fprint(t.tty, b.String())
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// runTimings collects what the requests to github of a run cost, for the
+// summary of -timings.
+type runTimings struct {
+	mu        sync.Mutex
+	start     time.Time
+	requests  int
+	cacheHits int
+	bytes     int64
+	apiTime   time.Duration
+
+	// The rate limit as of the latest response.
+	rateRemaining string
+	rateLimit     string
+	rateReset     string
+}
+
+var timings = &runTimings{start: time.Now()}
+
+// cacheHit counts a response served without connecting to github, like a
+// replayed cassette.
+func (t *runTimings) cacheHit() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cacheHits++
+}
+
+func (t *runTimings) observe(res *http.Response, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	t.apiTime += duration
+
+	if res == nil {
+		return
+	}
+
+	if res.StatusCode == http.StatusNotModified {
+		t.cacheHits++
+	}
+
+	if remaining := res.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+		t.rateRemaining = remaining
+		t.rateLimit = res.Header.Get("X-RateLimit-Limit")
+		t.rateReset = res.Header.Get("X-RateLimit-Reset")
+	}
+}
+
+func (t *runTimings) transferred(n int64, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.bytes += n
+	t.apiTime += duration
+}
+
+// timingsTransport measures the requests to github, from sending them until
+// their body is read.
+type timingsTransport struct {
+	next http.RoundTripper
+}
+
+// timeHTTP installs the timings transport on the default http client.
+func timeHTTP() {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	http.DefaultClient.Transport = &timingsTransport{next: next}
+}
+
+func (t *timingsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	timings.observe(res, time.Since(start))
+
+	if err != nil {
+		return res, err
+	}
+
+	if req.ContentLength > 0 {
+		timings.transferred(req.ContentLength, 0)
+	}
+
+	res.Body = &timedBody{ReadCloser: res.Body}
+
+	return res, nil
+}
+
+// timedBody counts the bytes read from a response and the time spent
+// waiting for them.
+type timedBody struct {
+	io.ReadCloser
+}
+
+func (b *timedBody) Read(p []byte) (int, error) {
+	start := time.Now()
+
+	n, err := b.ReadCloser.Read(p)
+
+	timings.transferred(int64(n), time.Since(start))
+
+	return n, err
+}
+
+// printTimings writes the summary of -timings to stderr. Render time is
+// whatever the run spent outside of requests to github.
+func printTimings() {
+	timings.mu.Lock()
+	defer timings.mu.Unlock()
+
+	total := time.Since(timings.start)
+
+	render := total - timings.apiTime
+	if render < 0 {
+		render = 0
+	}
+
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "Timings:")
+	fmt.Fprintf(w, "  requests\t%d\n", timings.requests)
+	fmt.Fprintf(w, "  cache hits\t%d\n", timings.cacheHits)
+	fmt.Fprintf(w, "  transferred\t%s\n", formatBytes(timings.bytes))
+	fmt.Fprintf(w, "  api time\t%s\n", timings.apiTime.Round(time.Millisecond))
+	fmt.Fprintf(w, "  render time\t%s\n", render.Round(time.Millisecond))
+	fmt.Fprintf(w, "  total\t%s\n", total.Round(time.Millisecond))
+
+	if timings.rateRemaining != "" {
+		reset := ""
+		if unix, err := strconv.ParseInt(timings.rateReset, 10, 64); err == nil {
+			reset = ", resets at " + time.Unix(unix, 0).Format("15:04:05")
+		}
+
+		fmt.Fprintf(w, "  rate limit\t%s of %s left%s\n", timings.rateRemaining, timings.rateLimit, reset)
+	}
+
+	w.Flush()
+}
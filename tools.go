@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+)
+
+// The tools are the searches and repository lookups served to programs by
+// serve --mcp and -rpc.
+
+// toolArguments are the arguments of every tool, each tool reads the ones
+// it needs.
+type toolArguments struct {
+	Query    string `json:"query"`
+	Sort     string `json:"sort"`
+	Limit    int    `json:"limit"`
+	FullName string `json:"full_name"`
+}
+
+// callTool runs the tool called name, found is false for unknown tools.
+func callTool(ctx context.Context, name string, args toolArguments) (result interface{}, found bool, err error) {
+	switch name {
+	case "search_repos":
+		result, err = toolSearchRepos(ctx, args)
+	case "search_users":
+		result, err = toolSearchUsers(ctx, args)
+	case "get_repo":
+		result, err = toolGetRepo(ctx, args)
+	case "get_readme":
+		var owner, repo string
+		owner, repo, err = parseRepoName(args.FullName)
+		if err == nil {
+			result, err = findReadme(ctx, owner, repo)
+		}
+	default:
+		return nil, false, nil
+	}
+
+	return result, true, err
+}
+
+func toolSearchRepos(ctx context.Context, args toolArguments) ([]repository, error) {
+	if args.Query == "" {
+		return nil, usageError("provide a query")
+	}
+
+	repos, err := searchRepositories(ctx, args.Query, args.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = 30
+	}
+
+	if len(repos) > limit {
+		repos = repos[:limit]
+	}
+
+	return repos, nil
+}
+
+func toolSearchUsers(ctx context.Context, args toolArguments) ([]userSummary, error) {
+	if args.Query == "" {
+		return nil, usageError("provide a query")
+	}
+
+	logins, err := findUsers(ctx, args.Query, args.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]userSummary, len(logins))
+	for i, login := range logins {
+		users[i] = userSummary{Login: login, HTMLURL: "https://github.com/" + login}
+	}
+
+	return users, nil
+}
+
+func toolGetRepo(ctx context.Context, args toolArguments) (repository, error) {
+	owner, name, err := parseRepoName(args.FullName)
+	if err != nil {
+		return repository{}, err
+	}
+
+	repo, err := githubClient().GetRepository(ctx, owner, name)
+	if err != nil {
+		return repository{}, requestError(ctx, err)
+	}
+
+	indexRepositories(ctx, []repository{*repo})
+
+	return *repo, nil
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Span kinds and status codes of the OpenTelemetry protocol.
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+	spanKindClient   = 3
+
+	spanStatusOK    = 1
+	spanStatusError = 2
+)
+
+// tracerBatchSize is how many ended spans are buffered before they are
+// exported, so long running watches and servers export as they go.
+const tracerBatchSize = 100
+
+// span is an operation traced, like the run of a command or a request to
+// github.
+type span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+	attrs    map[string]interface{}
+	err      error
+}
+
+// tracer exports spans as json over the OTLP http protocol to the collector
+// of OTEL_EXPORTER_OTLP_ENDPOINT. A nil tracer traces nothing.
+type tracer struct {
+	mu       sync.Mutex
+	endpoint string
+	headers  map[string]string
+	service  string
+	client   *http.Client
+	log      *slog.Logger
+	root     *span
+	ended    []*span
+}
+
+var tracing *tracer
+
+// setupTracing turns tracing on when an OTLP endpoint is configured with the
+// standard OpenTelemetry environment variables.
+func setupTracing(log *slog.Logger) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			return
+		}
+		endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+
+	if protocol := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); protocol != "" && protocol != "http/json" {
+		log.Warn("tracing: only the http/json otlp protocol is supported", "protocol", protocol)
+	}
+
+	service := os.Getenv("OTEL_SERVICE_NAME")
+	if service == "" {
+		service = "go-cli-flag"
+	}
+
+	tracing = &tracer{
+		endpoint: endpoint,
+		headers:  parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+		service:  service,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		log:      log,
+	}
+
+	log.Debug("tracing", "endpoint", endpoint, "service", service)
+}
+
+// parseOTLPHeaders parses OTEL_EXPORTER_OTLP_HEADERS, key=value pairs
+// separated by commas.
+func parseOTLPHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+
+	for _, pair := range strings.Split(value, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if ok && strings.TrimSpace(key) != "" {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return headers
+}
+
+// startCommand starts the span of a command, the parent of the spans of its
+// requests. With TRACEPARENT set, like in traced ci jobs, it joins that
+// trace.
+func (t *tracer) startCommand(name string, args []string) *span {
+	if t == nil {
+		return nil
+	}
+
+	s := t.newSpan(name, spanKindInternal, nil)
+	s.attrs["process.command_args"] = strings.Join(sanitizeArgs(args), " ")
+
+	if traceID, parentID, ok := parseTraceparent(os.Getenv("TRACEPARENT")); ok {
+		s.traceID, s.parentID = traceID, parentID
+	}
+
+	t.mu.Lock()
+	t.root = s
+	t.mu.Unlock()
+
+	return s
+}
+
+// startSpan starts a span under the span of the command, or of the request
+// of a client serve is handling.
+func (t *tracer) startSpan(name string, kind int) *span {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	parent := t.root
+	t.mu.Unlock()
+
+	if r := getActiveRequest(); r != nil && r.span != nil {
+		parent = r.span
+	}
+
+	return t.newSpan(name, kind, parent)
+}
+
+func (t *tracer) newSpan(name string, kind int, parent *span) *span {
+	s := &span{name: name, kind: kind, start: time.Now(), attrs: make(map[string]interface{})}
+
+	rand.Read(s.spanID[:])
+
+	if parent != nil {
+		s.traceID, s.parentID = parent.traceID, parent.spanID
+	} else {
+		rand.Read(s.traceID[:])
+	}
+
+	return s
+}
+
+// end ends the span, failed with err if not nil, and exports the buffered
+// spans once there are enough of them.
+func (t *tracer) end(s *span, err error) {
+	if t == nil || s == nil {
+		return
+	}
+
+	s.end, s.err = time.Now(), err
+
+	t.mu.Lock()
+	t.ended = append(t.ended, s)
+	full := len(t.ended) >= tracerBatchSize
+	t.mu.Unlock()
+
+	if full {
+		t.flush()
+	}
+}
+
+// flush exports the ended spans. A failed export is logged and does not
+// fail the command.
+func (t *tracer) flush() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	spans := t.ended
+	t.ended = nil
+	t.mu.Unlock()
+
+	if len(spans) == 0 {
+		return
+	}
+
+	err := t.export(spans)
+	if err != nil {
+		t.log.Warn("tracing: export failed", "spans", len(spans), "err", err)
+	}
+}
+
+func (t *tracer) export(spans []*span) error {
+	otlpSpans := make([]map[string]interface{}, 0, len(spans))
+
+	for _, s := range spans {
+		otlpSpans = append(otlpSpans, s.otlp())
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(map[string]interface{}{"service.name": t.service}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "go-cli-flag"},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("the collector answered %s", res.Status)
+	}
+
+	return nil
+}
+
+// otlp returns the span in the json encoding of OTLP, which has ids in hex
+// and times as strings of unix nanoseconds.
+func (s *span) otlp() map[string]interface{} {
+	status := map[string]interface{}{"code": spanStatusOK}
+	if s.err != nil {
+		status = map[string]interface{}{"code": spanStatusError, "message": redact(s.err.Error())}
+	}
+
+	otlp := map[string]interface{}{
+		"traceId":           hex.EncodeToString(s.traceID[:]),
+		"spanId":            hex.EncodeToString(s.spanID[:]),
+		"name":              s.name,
+		"kind":              s.kind,
+		"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+		"attributes":        otlpAttributes(s.attrs),
+		"status":            status,
+	}
+
+	if s.parentID != [8]byte{} {
+		otlp["parentSpanId"] = hex.EncodeToString(s.parentID[:])
+	}
+
+	return otlp
+}
+
+func otlpAttributes(attrs map[string]interface{}) []map[string]interface{} {
+	list := make([]map[string]interface{}, 0, len(attrs))
+
+	for key, value := range attrs {
+		var v map[string]interface{}
+
+		switch value := value.(type) {
+		case int:
+			v = map[string]interface{}{"intValue": strconv.Itoa(value)}
+		case bool:
+			v = map[string]interface{}{"boolValue": value}
+		default:
+			v = map[string]interface{}{"stringValue": redact(fmt.Sprint(value))}
+		}
+
+		list = append(list, map[string]interface{}{"key": key, "value": v})
+	}
+
+	return list
+}
+
+// parseTraceparent parses a w3c traceparent header,
+// version-traceid-parentid-flags.
+func parseTraceparent(value string) ([16]byte, [8]byte, bool) {
+	var traceID [16]byte
+	var parentID [8]byte
+
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return traceID, parentID, false
+	}
+
+	_, err := hex.Decode(traceID[:], []byte(parts[1]))
+	if err != nil || traceID == [16]byte{} {
+		return traceID, parentID, false
+	}
+
+	_, err = hex.Decode(parentID[:], []byte(parts[2]))
+	if err != nil || parentID == [8]byte{} {
+		return traceID, parentID, false
+	}
+
+	return traceID, parentID, true
+}
+
+// tracingTransport traces every request to github as a client span of the
+// command and sends the trace on in the traceparent header.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// traceHTTP installs the tracing transport on the default http client.
+func traceHTTP() {
+	next := http.DefaultClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	http.DefaultClient.Transport = &tracingTransport{next: next}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := tracing.startSpan(req.Method, spanKindClient)
+	s.attrs["http.request.method"] = req.Method
+	s.attrs["url.full"] = req.URL.String()
+	s.attrs["server.address"] = req.URL.Hostname()
+
+	req = req.Clone(req.Context())
+	req.Header.Set("traceparent", s.traceparent())
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		tracing.end(s, err)
+		return res, err
+	}
+
+	s.attrs["http.response.status_code"] = res.StatusCode
+
+	if res.StatusCode >= 400 {
+		err = fmt.Errorf("github answered %s", res.Status)
+	}
+
+	tracing.end(s, err)
+
+	return res, nil
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// repoBrowser is the state of the interactive search result browser.
+type repoBrowser struct {
+	title    string
+	repos    []repository
+	selected int
+	offset   int
+	status   string
+	readmes  map[string][]string
+}
+
+// browseRepositories shows repos in a full screen list with a detail pane for
+// the selected repository until the user quits.
+func browseRepositories(ctx context.Context, title string, repos []repository) error {
+	if len(repos) == 0 {
+		return fmt.Errorf("no results for %s", title)
+	}
+
+	t, err := openTerminal(ctx)
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+
+	b := &repoBrowser{
+		title:   title,
+		repos:   repos,
+		readmes: make(map[string][]string),
+	}
+
+	for {
+		rows, cols := t.size()
+		t.draw(b.render(ctx, rows, cols))
+
+		key, err := t.readKey()
+		if err != nil {
+			return err
+		}
+
+		b.status = ""
+		current := b.repos[b.selected]
+		listRows := b.listRows(rows)
+
+		switch key {
+		case "q", "esc", "ctrl-c":
+			return nil
+		case "up", "k":
+			b.move(-1)
+		case "down", "j":
+			b.move(1)
+		case "pgup":
+			b.move(-listRows)
+		case "pgdown":
+			b.move(listRows)
+		case "g":
+			b.move(-len(b.repos))
+		case "G":
+			b.move(len(b.repos))
+		case "enter", "o":
+			openBrowser(ctx, current.HTMLURL)
+			b.status = "Opened " + current.HTMLURL
+		case "c":
+			err := copyToClipboard(ctx, current.HTMLURL)
+			if err != nil {
+				b.status = err.Error()
+			} else {
+				b.status = "Copied " + current.HTMLURL
+			}
+		case "s":
+			b.status = b.star(ctx, current)
+		case "b":
+			_, err := addBookmark(current.FullName, nil, "")
+			if err != nil {
+				b.status = err.Error()
+			} else {
+				b.status = "Bookmarked " + current.FullName
+			}
+		}
+	}
+}
+
+func (b *repoBrowser) move(delta int) {
+	b.selected += delta
+
+	if b.selected < 0 {
+		b.selected = 0
+	}
+
+	if b.selected >= len(b.repos) {
+		b.selected = len(b.repos) - 1
+	}
+}
+
+func (b *repoBrowser) star(ctx context.Context, r repository) string {
+	err := requireToken()
+	if err != nil {
+		return err.Error()
+	}
+
+	owner, name, _ := strings.Cut(r.FullName, "/")
+
+	err = requestError(ctx, githubClient().Star(ctx, owner, name))
+	if err != nil {
+		return fmt.Sprintf("Failed to star %s: %v", r.FullName, err)
+	}
+
+	return "Starred " + r.FullName
+}
+
+// listRows returns how many rows of the screen the result list gets, the
+// rest goes to the detail pane.
+func (b *repoBrowser) listRows(rows int) int {
+	n := (rows - 3) / 2
+	if n < 1 {
+		n = 1
+	}
+
+	return n
+}
+
+// readme returns the first lines of the readme of the repository, fetched
+// once and kept for when the repository is selected again.
+func (b *repoBrowser) readme(ctx context.Context, r repository) []string {
+	if lines, ok := b.readmes[r.FullName]; ok {
+		return lines
+	}
+
+	owner, name, _ := parseRepoName(r.FullName)
+
+	readme, err := findReadme(ctx, owner, name)
+	if err != nil {
+		readme = "No readme"
+	}
+
+	lines := strings.Split(strings.NewReplacer("\t", "    ", "\r", "").Replace(readme), "\n")
+	b.readmes[r.FullName] = lines
+
+	return lines
+}
+
+func (b *repoBrowser) render(ctx context.Context, rows, cols int) []string {
+	listRows := b.listRows(rows)
+
+	// Scroll the list so the selected repository stays visible.
+	if b.selected < b.offset {
+		b.offset = b.selected
+	}
+	if b.selected >= b.offset+listRows {
+		b.offset = b.selected - listRows + 1
+	}
+
+	lines := []string{ansiBold + truncate(fmt.Sprintf("%s (%d results)", b.title, len(b.repos)), cols) + ansiReset}
+
+	for i := b.offset; i < b.offset+listRows; i++ {
+		if i >= len(b.repos) {
+			lines = append(lines, "")
+			continue
+		}
+
+		r := b.repos[i]
+		line := truncate(fmt.Sprintf(" %7d  %s", r.StargazersCount, r.FullName), cols)
+
+		if i == b.selected {
+			line = ansiReverse + line + strings.Repeat(" ", cols-len([]rune(line))) + ansiReset
+		}
+
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, ansiDim+strings.Repeat("─", cols)+ansiReset)
+
+	r := b.repos[b.selected]
+	detail := []string{
+		ansiBold + r.FullName + ansiReset,
+		r.Description,
+		repositoryStats(r),
+		"",
+	}
+
+	for _, line := range b.readme(ctx, r) {
+		detail = append(detail, ansiDim+line+ansiReset)
+	}
+
+	// One row is left for the key help at the bottom.
+	detailRows := rows - len(lines) - 1
+
+	for i := 0; i < detailRows; i++ {
+		if i < len(detail) {
+			lines = append(lines, truncateStyled(detail[i], cols))
+		} else {
+			lines = append(lines, "")
+		}
+	}
+
+	footer := "↑/↓ move  enter/o open  c copy url  s star  b bookmark  q quit"
+	if b.status != "" {
+		footer = b.status
+	}
+
+	return append(lines, ansiDim+truncate(footer, cols)+ansiReset)
+}
+
+func repositoryStats(r repository) string {
+	stats := []string{fmt.Sprintf("%d stars", r.StargazersCount), fmt.Sprintf("%d forks", r.ForksCount)}
+
+	if r.Language != "" {
+		stats = append(stats, r.Language)
+	}
+
+	return strings.Join(stats, ", ") + fmt.Sprintf(", pushed %s ago", formatAge(r.PushedAt))
+}
+
+// truncateStyled truncates a line that is wrapped in ansi codes, the codes
+// do not take up any columns.
+func truncateStyled(line string, n int) string {
+	for _, code := range []string{ansiBold, ansiDim} {
+		if strings.HasPrefix(line, code) && strings.HasSuffix(line, ansiReset) {
+			inner := strings.TrimSuffix(strings.TrimPrefix(line, code), ansiReset)
+			return code + truncate(inner, n) + ansiReset
+		}
+	}
+
+	return truncate(line, n)
+}
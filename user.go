@@ -0,0 +1,477 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gurleensethi/go-cli-flag/pkg/github"
+)
+
+var userUsage = `Specify a user command to execute:
+  - view: Show the profile of a user
+  - repos: List the repositories of a user
+  - followers: List the followers of a user
+  - following: List the users a user follows
+  - starred: List the repositories starred by a user
+  - sponsors: Show the sponsorship details of a user
+  - follow: Follow users
+  - unfollow: Unfollow users
+  - events: Show the recent public events of a user`
+
+func executeUser(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageError(userUsage)
+	}
+
+	command := args[0]
+
+	loggerFrom(ctx).Debug("user", "command", command)
+
+	if command != "follow" && command != "unfollow" && piped(args[1:]) {
+		return forEachIdentifier(args[1:], func(args []string) error {
+			return executeUser(ctx, append([]string{command}, args...))
+		})
+	}
+
+	switch command {
+	case "view":
+		return executeUserView(ctx, args[1:])
+	case "repos":
+		return executeUserRepos(ctx, args[1:])
+	case "followers", "following":
+		return executeUserFollows(ctx, command, args[1:])
+	case "starred":
+		return executeUserStarred(ctx, args[1:])
+	case "sponsors":
+		return executeUserSponsors(ctx, args[1:])
+	case "follow", "unfollow":
+		return executeUserFollow(ctx, command, args[1:])
+	case "events":
+		return executeUserEvents(ctx, args[1:])
+	default:
+		return usageError(fmt.Sprintf("invalid user command: '%s'", command))
+	}
+}
+
+type userProfile = github.UserProfile
+
+func executeUserView(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("user view", flag.ExitOnError)
+
+	web := flagSet.Bool("web", false, "open the profile in the browser")
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "User")
+
+	if len(args) == 0 {
+		return usageError("provide a user: user view <login>")
+	}
+
+	login, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("user view", "login", login)
+
+	if *web {
+		return openBrowser(ctx, "https://github.com/"+login)
+	}
+
+	profile, err := githubClient().GetUser(ctx, login)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	indexUsers(ctx, []userProfile{*profile})
+
+	if jsonOutput() {
+		return printJSON(profile)
+	}
+
+	title := profile.Login
+	if profile.Name != "" {
+		title = fmt.Sprintf("%s (%s)", profile.Name, profile.Login)
+	}
+
+	fmt.Println(bold(title))
+
+	if profile.Bio != "" {
+		fmt.Println(profile.Bio)
+	}
+
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	if profile.Company != "" {
+		fmt.Fprintf(w, "Company:\t%s\n", profile.Company)
+	}
+	if profile.Location != "" {
+		fmt.Fprintf(w, "Location:\t%s\n", profile.Location)
+	}
+
+	fmt.Fprintf(w, "Followers:\t%d\n", profile.Followers)
+	fmt.Fprintf(w, "Following:\t%d\n", profile.Following)
+	fmt.Fprintf(w, "Public repos:\t%d\n", profile.PublicRepos)
+	fmt.Fprintf(w, "Member for:\t%s (since %s)\n", formatAge(profile.CreatedAt), formatDate(profile.CreatedAt))
+	fmt.Fprintf(w, "Profile:\t%s\n", profile.HTMLURL)
+
+	return w.Flush()
+}
+
+// formatAge describes how long ago t was in the largest fitting unit, e.g.
+// "3 years" or "5 days".
+func formatAge(t time.Time) string {
+	d := time.Since(t)
+
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"year", 365 * 24 * time.Hour},
+		{"month", 30 * 24 * time.Hour},
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+	}
+
+	for _, u := range units {
+		if n := int(d / u.size); n > 0 {
+			if n == 1 {
+				return fmt.Sprintf("1 %s", u.name)
+			}
+			return fmt.Sprintf("%d %ss", n, u.name)
+		}
+	}
+
+	return "less than a minute"
+}
+
+func executeUserRepos(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("user repos", flag.ExitOnError)
+
+	filter := addRepoFilterFlags(flagSet)
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "User")
+
+	if len(args) == 0 {
+		return usageError("provide a user: user repos <login>")
+	}
+
+	err := filter.validate()
+	if err != nil {
+		return err
+	}
+
+	login, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("user repos", "login", login, "filter", *filter)
+
+	if *offline {
+		repos, err := offlineOwnerRepositories(ctx, login, filter.sort, page.max(), filter.keep)
+		if err != nil {
+			return err
+		}
+
+		return printRepositories(repos)
+	}
+
+	repos, err := collectPages(ctx, githubClient().ListUserRepositories(ctx, login, filter.sort), page.max(), filter.keep)
+	if err != nil {
+		return err
+	}
+
+	indexRepositories(ctx, repos)
+
+	return printRepositories(repos)
+}
+
+type userSummary = github.User
+
+// executeUserFollows lists the followers or the followed users of a user,
+// depending on the relation which is either followers or following.
+func executeUserFollows(ctx context.Context, relation string, args []string) error {
+	flagSet := flag.NewFlagSet("user "+relation, flag.ExitOnError)
+
+	mutual := flagSet.Bool("mutual", false, "only show users that both follow and are followed by the user")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+
+	if len(args) == 0 {
+		return usageErrorf("provide a user: user %s <login>", relation)
+	}
+
+	login, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("user "+relation, "login", login, "mutual", *mutual)
+
+	// Intersecting requires both complete lists, the limit is applied after.
+	limit := page.max()
+	if *mutual {
+		limit = 0
+	}
+
+	client := githubClient()
+
+	list, other := client.ListFollowers, client.ListFollowing
+	if relation == "following" {
+		list, other = client.ListFollowing, client.ListFollowers
+	}
+
+	users, err := collectPages(ctx, list(ctx, login), limit, nil)
+	if err != nil {
+		return err
+	}
+
+	indexUserSummaries(ctx, users)
+
+	if *mutual {
+		others, err := collectPages(ctx, other(ctx, login), 0, nil)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[string]bool)
+		for _, u := range others {
+			known[u.Login] = true
+		}
+
+		mutuals := make([]userSummary, 0)
+		for _, u := range users {
+			if known[u.Login] {
+				mutuals = append(mutuals, u)
+			}
+		}
+
+		users = mutuals
+		if max := page.max(); max > 0 && len(users) > max {
+			users = users[:max]
+		}
+	}
+
+	if jsonOutput() {
+		return printJSON(users)
+	}
+
+	for _, u := range users {
+		fmt.Println(u.Login)
+	}
+
+	return nil
+}
+
+type starredRepo = github.StarredRepository
+
+func executeUserStarred(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("user starred", flag.ExitOnError)
+
+	sort := flagSet.String("sort", "created", "sort by when the repository was starred (created) or last pushed to (updated)")
+	page := addPageFlags(flagSet)
+
+	args = parseArgs(flagSet, args)
+
+	// Without a login the stars of the authenticated user are listed.
+	login := ""
+
+	if len(args) > 0 {
+		var err error
+
+		login, err = parseLogin(args[0])
+		if err != nil {
+			return err
+		}
+	} else if err := requireToken(); err != nil {
+		return usageError("provide a user or authenticate: user starred [login]")
+	}
+
+	loggerFrom(ctx).Debug("user starred", "login", login, "sort", *sort)
+
+	stars, err := collectPages(ctx, githubClient().ListStarred(ctx, login, *sort), page.max(), nil)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput() {
+		return printJSON(stars)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STARRED\tNAME\tSTARS\tLANGUAGE\tDESCRIPTION")
+
+	for _, s := range stars {
+		language := s.Repo.Language
+		if language == "" {
+			language = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", formatDate(s.StarredAt), s.Repo.FullName, s.Repo.StargazersCount, language, truncate(s.Repo.Description, 60))
+	}
+
+	return w.Flush()
+}
+
+func executeUserSponsors(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("user sponsors", flag.ExitOnError)
+
+	args = parseArgs(flagSet, args)
+	args = promptMissing(args, "User")
+
+	if len(args) == 0 {
+		return usageError("provide a user: user sponsors <login>")
+	}
+
+	login, err := parseLogin(args[0])
+	if err != nil {
+		return err
+	}
+
+	loggerFrom(ctx).Debug("user sponsors", "login", login)
+
+	// The graphql api always requires a token.
+	err = requireToken()
+	if err != nil {
+		return err
+	}
+
+	sponsorship, err := githubClient().GetSponsorship(ctx, login)
+	if err != nil {
+		return requestError(ctx, err)
+	}
+
+	tiers := sponsorship.Tiers
+
+	if jsonOutput() {
+		return printJSON(map[string]interface{}{
+			"login":       sponsorship.Login,
+			"sponsorable": sponsorship.Sponsorable,
+			"sponsors":    sponsorship.Sponsors,
+			"tiers":       tiers,
+		})
+	}
+
+	fmt.Printf("Sponsorable: %t\n", sponsorship.Sponsorable)
+	fmt.Printf("Sponsors:    %d\n", sponsorship.Sponsors)
+
+	if len(tiers) == 0 {
+		return nil
+	}
+
+	fmt.Println("\nTiers:")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, t := range tiers {
+		kind := "monthly"
+		if t.IsOneTime {
+			kind = "one-time"
+		}
+
+		fmt.Fprintf(w, "$%d\t%s\t%s\n", t.MonthlyPriceInDollars, kind, t.Name)
+	}
+
+	return w.Flush()
+}
+
+// executeUserFollow follows or unfollows users, depending on the command
+// which is either follow or unfollow. A login of "-" reads the logins from
+// stdin so the output of search-users can be piped in.
+func executeUserFollow(ctx context.Context, command string, args []string) error {
+	flagSet := flag.NewFlagSet("user "+command, flag.ExitOnError)
+
+	yes := flagSet.Bool("yes", false, "do not ask for confirmation when unfollowing or following many users")
+
+	args = parseArgs(flagSet, args)
+
+	logins := make([]string, 0)
+
+	for _, arg := range args {
+		if arg != "-" {
+			logins = append(logins, arg)
+			continue
+		}
+
+		identifiers, err := readIdentifiers()
+		if err != nil {
+			return err
+		}
+
+		logins = append(logins, identifiers...)
+	}
+
+	for i, arg := range logins {
+		login, err := parseLogin(arg)
+		if err != nil {
+			return err
+		}
+
+		logins[i] = login
+	}
+
+	if len(logins) == 0 {
+		return usageErrorf("provide a user: user %s <login>, - reads logins from stdin", command)
+	}
+
+	err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	client := githubClient()
+
+	send, done := client.Follow, "Followed"
+	if command == "unfollow" {
+		send, done = client.Unfollow, "Unfollowed"
+	}
+
+	if command == "unfollow" || len(logins) > 1 {
+		subject := fmt.Sprintf("%d users", len(logins))
+		if len(logins) == 1 {
+			subject = logins[0]
+		}
+
+		err := confirm(fmt.Sprintf("%s %s?", strings.ToUpper(command[:1])+command[1:], subject), *yes)
+		if err != nil {
+			return err
+		}
+	}
+
+	failed := 0
+
+	for _, login := range logins {
+		loggerFrom(ctx).Debug("user "+command, "login", login)
+
+		err := requestError(ctx, send(ctx, login))
+		if errors.Is(err, errDryRun) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", login, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("%s %s\n", done, login)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d users", command, failed, len(logins))
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// repositoryChange is a difference between two runs of a watched search.
+type repositoryChange struct {
+	Time       time.Time  `json:"time"`
+	Change     string     `json:"change"`
+	Repository repository `json:"repository"`
+	Details    []string   `json:"details,omitempty"`
+}
+
+// diffRepositories compares the results of a search with the previous run,
+// repositories are matched by name.
+func diffRepositories(previous, current []repository, now time.Time) []repositoryChange {
+	changes := make([]repositoryChange, 0)
+
+	before := make(map[string]repository)
+	for _, r := range previous {
+		before[r.FullName] = r
+	}
+
+	after := make(map[string]bool)
+
+	for _, r := range current {
+		after[r.FullName] = true
+
+		old, ok := before[r.FullName]
+		if !ok {
+			changes = append(changes, repositoryChange{Time: now, Change: "added", Repository: r})
+			continue
+		}
+
+		details := make([]string, 0)
+
+		if old.StargazersCount != r.StargazersCount {
+			details = append(details, fmt.Sprintf("stars %d -> %d", old.StargazersCount, r.StargazersCount))
+		}
+		if old.ForksCount != r.ForksCount {
+			details = append(details, fmt.Sprintf("forks %d -> %d", old.ForksCount, r.ForksCount))
+		}
+		if old.Description != r.Description {
+			details = append(details, "description changed")
+		}
+		if old.Archived != r.Archived {
+			details = append(details, "archived")
+		}
+
+		if len(details) > 0 {
+			changes = append(changes, repositoryChange{Time: now, Change: "changed", Repository: r, Details: details})
+		}
+	}
+
+	for _, r := range previous {
+		if !after[r.FullName] {
+			changes = append(changes, repositoryChange{Time: now, Change: "removed", Repository: r})
+		}
+	}
+
+	return changes
+}
+
+func printRepositoryChange(c repositoryChange) error {
+	if jsonOutput() {
+		return json.NewEncoder(os.Stdout).Encode(c)
+	}
+
+	timestamp := c.Time.Local().Format("15:04:05")
+
+	switch c.Change {
+	case "added":
+		fmt.Printf("%s + %s  %d stars  %s\n", timestamp, c.Repository.FullName, c.Repository.StargazersCount, truncate(c.Repository.Description, 60))
+	case "removed":
+		fmt.Printf("%s - %s\n", timestamp, c.Repository.FullName)
+	default:
+		fmt.Printf("%s ~ %s  %s\n", timestamp, c.Repository.FullName, strings.Join(c.Details, ", "))
+	}
+
+	return nil
+}
+
+// notifyNewRepositories sends a desktop notification about the repositories
+// added to the results of a watched search, naming the one with the most
+// stars.
+func notifyNewRepositories(ctx context.Context, term string, changes []repositoryChange) {
+	var top *repository
+	added := 0
+
+	for i, c := range changes {
+		if c.Change != "added" {
+			continue
+		}
+
+		added++
+		if top == nil || c.Repository.StargazersCount > top.StargazersCount {
+			top = &changes[i].Repository
+		}
+	}
+
+	if added == 0 {
+		return
+	}
+
+	title := fmt.Sprintf("%d new repositories for %s", added, term)
+	if added == 1 {
+		title = fmt.Sprintf("New repository for %s", term)
+	}
+
+	err := sendNotification(ctx, title, fmt.Sprintf("%s (%d stars)", top.FullName, top.StargazersCount))
+	if err != nil {
+		loggerFrom(ctx).Warn("sending the notification failed", "err", err)
+	}
+}
+
+// watchSearchRepos runs the search every interval and prints what changed
+// since the previous run. The first run prints every result as added.
+// Failed runs are reported and retried on the next tick so a hiccup does not
+// end a long running watch, it ends when ctx is done. With notify new results after the first run
+// also show up as desktop notifications, and with an alerter they are posted
+// to its webhook.
+func watchSearchRepos(ctx context.Context, term, sort string, interval time.Duration, notify bool, alert *alerter) error {
+	var previous []repository
+
+	for {
+		current, err := searchRepositories(ctx, term, sort)
+		if err != nil && ctx.Err() != nil {
+			// Cancelled or past the -deadline, every later run fails too.
+			return err
+		} else if err != nil {
+			metrics.inc("gcf_watch_runs_total", "result", "failure")
+			loggerFrom(ctx).Warn("watch: search failed", "term", term, "err", err)
+		} else {
+			metrics.inc("gcf_watch_runs_total", "result", "success")
+
+			changes := diffRepositories(previous, current, time.Now())
+
+			for _, c := range changes {
+				metrics.inc("gcf_watch_changes_total", "change", c.Change)
+
+				err := printRepositoryChange(c)
+				if err != nil {
+					return err
+				}
+			}
+
+			if previous != nil {
+				if notify {
+					notifyNewRepositories(ctx, term, changes)
+				}
+
+				alert.send(ctx, term, changes)
+			}
+
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
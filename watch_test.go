@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withTransport sends the requests of the github client with next for the
+// test.
+func withTransport(t *testing.T, next http.RoundTripper) {
+	t.Helper()
+
+	saved := http.DefaultClient.Transport
+	http.DefaultClient.Transport = next
+	t.Cleanup(func() {
+		http.DefaultClient.Transport = saved
+	})
+}
+
+func TestWatchSearchReposStopsWhenCancelled(t *testing.T) {
+	tests := []struct {
+		name string
+		// fail fails the search instead of answering it.
+		fail bool
+	}{
+		{name: "while waiting"},
+		{name: "after a failed search", fail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Results are indexed locally.
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			searches := 0
+
+			withTransport(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				searches++
+				cancel()
+
+				if tt.fail {
+					return nil, req.Context().Err()
+				}
+
+				return respondJSON(req, `{"total_count": 0, "items": []}`), nil
+			}))
+
+			done := make(chan error, 1)
+			go func() {
+				done <- watchSearchRepos(ctx, "cli", "stars", time.Hour, false, nil)
+			}()
+
+			select {
+			case err := <-done:
+				if err == nil {
+					t.Error("watchSearchRepos = nil, want the error of the cancelled context")
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("watchSearchRepos did not return after the context was cancelled")
+			}
+
+			if searches != 1 {
+				t.Errorf("searches = %d, want 1", searches)
+			}
+		})
+	}
+}
+
+func respondJSON(req *http.Request, body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
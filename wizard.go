@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var repoSortOptions = []string{"best-match", "stars", "forks", "updated"}
+
+// searchWizard walks the user through the common search qualifiers and
+// returns the search query and sort order built from the answers. Keywords
+// are the search terms given on the command line, if any.
+func searchWizard(keywords string) (string, string, error) {
+	if !canPrompt() {
+		return "", "", errors.New("the search wizard needs a terminal")
+	}
+
+	if keywords == "" {
+		answer, err := prompt("Keywords (optional)")
+		if err != nil {
+			return "", "", err
+		}
+		keywords = answer
+	}
+
+	language, err := prompt("Language, e.g. go (optional)")
+	if err != nil {
+		return "", "", err
+	}
+
+	minStars, err := promptNumber("Minimum stars (optional)")
+	if err != nil {
+		return "", "", err
+	}
+
+	maxStars, err := promptNumber("Maximum stars (optional)")
+	if err != nil {
+		return "", "", err
+	}
+
+	topic, err := prompt("Topic, e.g. cli (optional)")
+	if err != nil {
+		return "", "", err
+	}
+
+	sort := ""
+	for {
+		answer, err := prompt(fmt.Sprintf("Sort by %s (default best-match)", strings.Join(repoSortOptions, ", ")))
+		if err != nil {
+			return "", "", err
+		}
+
+		if answer == "" || answer == "best-match" {
+			break
+		}
+
+		if containsString(repoSortOptions, answer) {
+			sort = answer
+			break
+		}
+
+		fmt.Fprintf(os.Stderr, "Pick one of %s\n", strings.Join(repoSortOptions, ", "))
+	}
+
+	qualifiers := make([]string, 0)
+
+	if keywords != "" {
+		qualifiers = append(qualifiers, keywords)
+	}
+
+	if language != "" {
+		qualifiers = append(qualifiers, "language:"+language)
+	}
+
+	switch {
+	case minStars != "" && maxStars != "":
+		qualifiers = append(qualifiers, fmt.Sprintf("stars:%s..%s", minStars, maxStars))
+	case minStars != "":
+		qualifiers = append(qualifiers, "stars:>="+minStars)
+	case maxStars != "":
+		qualifiers = append(qualifiers, "stars:<="+maxStars)
+	}
+
+	if topic != "" {
+		qualifiers = append(qualifiers, "topic:"+topic)
+	}
+
+	if len(qualifiers) == 0 {
+		return "", "", errors.New("nothing to search for, answer at least one question")
+	}
+
+	return strings.Join(qualifiers, " "), sort, nil
+}
+
+// promptNumber prompts until the answer is empty or a non negative number.
+func promptNumber(label string) (string, error) {
+	for {
+		answer, err := prompt(label)
+		if err != nil {
+			return "", err
+		}
+
+		if n, err := strconv.Atoi(answer); answer == "" || err == nil && n >= 0 {
+			return answer, nil
+		}
+
+		fmt.Fprintln(os.Stderr, "Enter a number or leave it empty")
+	}
+}